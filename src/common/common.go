@@ -1,15 +1,38 @@
 package common
 
 import (
+	"fmt"
 	"golang.org/x/image/draw"
 	"image"
 	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+var (
+	claimedSerials = map[string]string{}
+	mutexSerials   sync.Mutex
+)
+
+// ResultCode is a result of device operation. Values are sent to API clients as status codes
+type ResultCode uint8
+
+// Device operation results
+const (
+	Failure         ResultCode = 0
+	Success         ResultCode = 1
+	ProfileNotFound ResultCode = 2
+	Protected       ResultCode = 3 // Default profile can not be changed this way
+	InvalidInput    ResultCode = 4
+	AlreadyExists   ResultCode = 5
+	Incompatible    ResultCode = 6 // Data belongs to another device or layout
+)
+
 // FileExists will check if given filename exists
 func FileExists(filename string) bool {
 	_, err := os.Stat(filename)
@@ -19,6 +42,71 @@ func FileExists(filename string) bool {
 	return err == nil
 }
 
+// GetRunningProcesses will return executable names of running processes with start time of their newest instance.
+// Start time is in clock ticks after system boot. Processes without a command line, such as kernel threads, are skipped
+func GetRunningProcesses() (map[string]uint64, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make(map[string]uint64)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, e := strconv.Atoi(entry.Name()); e != nil {
+			continue // Not a process directory
+		}
+
+		// Process can exit at any time, missing files are ignored
+		cmdline, e := os.ReadFile("/proc/" + entry.Name() + "/cmdline")
+		if e != nil || len(cmdline) == 0 {
+			continue
+		}
+		stat, e := os.ReadFile("/proc/" + entry.Name() + "/stat")
+		if e != nil {
+			continue
+		}
+
+		// Executable name is the first argument without its path. Windows paths are used by Wine / Proton games
+		executable := strings.SplitN(string(cmdline), "\x00", 2)[0]
+		if i := strings.LastIndexAny(executable, "/\\"); i >= 0 {
+			executable = executable[i+1:]
+		}
+		if len(executable) == 0 {
+			continue
+		}
+
+		// Process name in stat can contain spaces and parentheses, fields are read after the last parenthesis.
+		// Start time is field 22, which is 20th field after process name and state
+		fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+		if len(fields) < 20 {
+			continue
+		}
+		startTime, e := strconv.ParseUint(fields[19], 10, 64)
+		if e != nil {
+			continue
+		}
+
+		if startTime >= processes[executable] {
+			processes[executable] = startTime
+		}
+	}
+	return processes, nil
+}
+
+// GetMuteState will return mute state of default audio sink. Error is returned when sound server is not reachable
+func GetMuteState() (bool, error) {
+	output, err := exec.Command("pactl", "get-sink-mute", "@DEFAULT_SINK@").Output()
+	if err != nil {
+		return false, err
+	}
+
+	// Output format is "Mute: yes" or "Mute: no"
+	return strings.Contains(strings.ToLower(string(output)), "yes"), nil
+}
+
 // Lerp performs linear interpolation between two values
 func Lerp(a, b, t float64) float64 {
 	return a + t*(b-a)
@@ -157,3 +245,74 @@ func ResizeImage(src image.Image, width, height int) image.Image {
 	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
 	return dst
 }
+
+// ClaimDeviceSerial will register device serial for given HID path.
+// Identical devices can report the same serial, in that case a serial is extended with interface number and
+// usage page of a device, which don't change between restarts, so each device keeps separate profiles.
+// Devices matching in both are numbered in claim order. Returns final serial and true if serial was changed.
+func ClaimDeviceSerial(serial, path string, interfaceNbr int, usagePage uint16) (string, bool) {
+	mutexSerials.Lock()
+	defer mutexSerials.Unlock()
+
+	if claimedPath, ok := claimedSerials[serial]; !ok || claimedPath == path {
+		claimedSerials[serial] = path
+		return serial, false
+	}
+
+	// Profile filenames allow only letters and numbers in serial
+	base := fmt.Sprintf("%s%02X%04X", serial, uint8(interfaceNbr), usagePage)
+	unique := base
+	for i := 2; ; i++ {
+		if claimedPath, ok := claimedSerials[unique]; !ok || claimedPath == path {
+			break
+		}
+		unique = base + strconv.Itoa(i)
+	}
+	claimedSerials[unique] = path
+	return unique, true
+}
+
+// ReleaseDeviceSerial will remove device serial registered via ClaimDeviceSerial
+func ReleaseDeviceSerial(serial string) {
+	mutexSerials.Lock()
+	defer mutexSerials.Unlock()
+	delete(claimedSerials, serial)
+}
+
+// WriteFileAtomic will write data to a temporary file in the same directory and rename it over the target.
+// If the process is interrupted mid-write, the target file remains untouched.
+func WriteFileAtomic(filename string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpFilename := file.Name()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpFilename)
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpFilename)
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		_ = os.Remove(tmpFilename)
+		return err
+	}
+
+	if err = os.Chmod(tmpFilename, 0644); err != nil {
+		_ = os.Remove(tmpFilename)
+		return err
+	}
+
+	if err = os.Rename(tmpFilename, filename); err != nil {
+		_ = os.Remove(tmpFilename)
+		return err
+	}
+	return nil
+}
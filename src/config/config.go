@@ -4,33 +4,53 @@ import (
 	"OpenLinkHub/src/common"
 	"encoding/json"
 	"os"
+	"sync"
 )
 
 type Configuration struct {
-	Debug           bool     `json:"debug"`
-	ListenPort      int      `json:"listenPort"`
-	ListenAddress   string   `json:"listenAddress"`
-	CPUSensorChip   string   `json:"cpuSensorChip"`
-	Manual          bool     `json:"manual"`
-	Frontend        bool     `json:"frontend"`
-	RefreshOnStart  bool     `json:"refreshOnStart"`
-	Metrics         bool     `json:"metrics"`
-	DbusMonitor     bool     `json:"dbusMonitor"`
-	Memory          bool     `json:"memory"`
-	MemorySmBus     string   `json:"memorySmBus"`
-	MemoryType      int      `json:"memoryType"`
-	Exclude         []uint16 `json:"exclude"`
-	DecodeMemorySku bool     `json:"decodeMemorySku"`
-	MemorySku       string   `json:"memorySku"`
-	ConfigPath      string   `json:",omitempty"`
+	Debug                   bool     `json:"debug"`
+	Simulate                bool     `json:"simulate"`
+	ListenPort              int      `json:"listenPort"`
+	ListenAddress           string   `json:"listenAddress"`
+	CPUSensorChip           string   `json:"cpuSensorChip"`
+	Manual                  bool     `json:"manual"`
+	Frontend                bool     `json:"frontend"`
+	RefreshOnStart          bool     `json:"refreshOnStart"`
+	Metrics                 bool     `json:"metrics"`
+	DbusMonitor             bool     `json:"dbusMonitor"`
+	Memory                  bool     `json:"memory"`
+	MemorySmBus             string   `json:"memorySmBus"`
+	MemoryType              int      `json:"memoryType"`
+	Exclude                 []uint16 `json:"exclude"`
+	DecodeMemorySku         bool     `json:"decodeMemorySku"`
+	MemorySku               string   `json:"memorySku"`
+	KeyboardLayout          string   `json:"keyboardLayout"`
+	LedInitDelay            int      `json:"ledInitDelay"`
+	TemperaturePollInterval int      `json:"temperaturePollInterval"`
+	StartupEffect           string   `json:"startupEffect"`
+	StartupDurationMs       int      `json:"startupDurationMs"`
+	DialInterface           int      `json:"dialInterface"`
+	KeepSoftwareModeOnExit  bool     `json:"keepSoftwareModeOnExit"`
+	PactlVolume             bool     `json:"pactlVolume"`
+	ConfigPath              string   `json:",omitempty"`
 }
 
 var (
 	location      = ""
 	configuration Configuration
+	mutexConfig   sync.RWMutex
 	upgrade       = map[string]any{
-		"decodeMemorySku": true,
-		"memorySku":       "",
+		"decodeMemorySku":         true,
+		"memorySku":               "",
+		"simulate":                false,
+		"keyboardLayout":          "",
+		"ledInitDelay":            500,
+		"temperaturePollInterval": 1000,
+		"startupEffect":           "",
+		"startupDurationMs":       3000,
+		"dialInterface":           2,
+		"keepSoftwareModeOnExit":  false,
+		"pactlVolume":             false,
 	}
 )
 
@@ -65,21 +85,30 @@ func Init() {
 func upgradeFile(cfg string) {
 	if !common.FileExists(cfg) {
 		value := &Configuration{
-			Debug:           false,
-			ListenPort:      27003,
-			ListenAddress:   "127.0.0.1",
-			CPUSensorChip:   "",
-			Manual:          false,
-			Frontend:        true,
-			RefreshOnStart:  false,
-			Metrics:         false,
-			DbusMonitor:     false,
-			Memory:          false,
-			MemorySmBus:     "i2c-0",
-			MemoryType:      4,
-			Exclude:         make([]uint16, 0),
-			DecodeMemorySku: true,
-			MemorySku:       "",
+			Debug:                   false,
+			Simulate:                false,
+			ListenPort:              27003,
+			ListenAddress:           "127.0.0.1",
+			CPUSensorChip:           "",
+			Manual:                  false,
+			Frontend:                true,
+			RefreshOnStart:          false,
+			Metrics:                 false,
+			DbusMonitor:             false,
+			Memory:                  false,
+			MemorySmBus:             "i2c-0",
+			MemoryType:              4,
+			Exclude:                 make([]uint16, 0),
+			DecodeMemorySku:         true,
+			MemorySku:               "",
+			KeyboardLayout:          "",
+			LedInitDelay:            500,
+			TemperaturePollInterval: 1000,
+			StartupEffect:           "",
+			StartupDurationMs:       3000,
+			DialInterface:           2,
+			KeepSoftwareModeOnExit:  false,
+			PactlVolume:             false,
 		}
 		saveConfigSettings(value)
 	} else {
@@ -142,5 +171,23 @@ func saveConfigSettings(data any) {
 
 // GetConfig will return structs.Configuration struct
 func GetConfig() Configuration {
+	mutexConfig.RLock()
+	defer mutexConfig.RUnlock()
 	return configuration
 }
+
+// SetTemperaturePollInterval will update temperature polling interval in milliseconds and save it to config file.
+// New interval is used by all devices without restart
+func SetTemperaturePollInterval(interval int) error {
+	mutexConfig.Lock()
+	configuration.TemperaturePollInterval = interval
+	value := configuration
+	mutexConfig.Unlock()
+
+	value.ConfigPath = "" // Runtime value, not stored in config file
+	buffer, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		return err
+	}
+	return common.WriteFileAtomic(location, buffer)
+}
@@ -1636,8 +1636,8 @@ func (d *Device) setDeviceColor(resetColor bool) {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // setAutoRefresh will refresh device data
@@ -43,11 +43,14 @@ import (
 	"OpenLinkHub/src/metrics"
 	"OpenLinkHub/src/rgb"
 	"OpenLinkHub/src/smbus"
+	"errors"
+	"fmt"
 	"github.com/sstallion/go-hid"
 	"os"
 	"reflect"
 	"slices"
 	"strconv"
+	"sync"
 )
 
 const (
@@ -105,6 +108,12 @@ type Device struct {
 	Hidden      bool
 }
 
+// KeyboardLayouts contains current and available keyboard layouts of a device
+type KeyboardLayouts struct {
+	Current   string   `json:"current"`
+	Available []string `json:"available"`
+}
+
 type Product struct {
 	ProductId uint16
 	Path      string
@@ -115,6 +124,7 @@ var (
 	vendorId           uint16 = 6940 // Corsair
 	interfaceId               = 0
 	devices                   = make(map[string]*Device, 0)
+	mutexDevices              = sync.RWMutex{}
 	products                  = make(map[string]Product, 0)
 	keyboards                 = []uint16{7127, 7165, 7166, 7110, 7083, 11024, 11015, 7109, 7091}
 	mouses                    = []uint16{7059, 7005, 6988, 7096, 7139, 7131, 11011, 7024}
@@ -124,7 +134,7 @@ var (
 
 // Stop will stop all active devices
 func Stop() {
-	for _, device := range devices {
+	for _, device := range activeDevices() {
 		methodName := "Stop"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -163,7 +173,7 @@ func GetDeviceTemplate(device interface{}) string {
 
 // UpdateMiscColor will process a POST request from a client for misc color change
 func UpdateMiscColor(deviceId string, keyId, keyOptions int, color rgb.Color) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceColor"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -187,7 +197,7 @@ func UpdateMiscColor(deviceId string, keyId, keyOptions int, color rgb.Color) ui
 
 // UpdateKeyboardColor will process POST request from a client for keyboard color change
 func UpdateKeyboardColor(deviceId string, keyId, keyOptions int, color rgb.Color) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceColor"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -209,9 +219,87 @@ func UpdateKeyboardColor(deviceId string, keyId, keyOptions int, color rgb.Color
 	return 0
 }
 
+// UpdateKeyboardColorHex will change color of all keyboard keys from hex string. Color is mirrored to mirror targets
+func UpdateKeyboardColorHex(deviceId, hex string) uint8 {
+	status := updateKeyboardColorHex(deviceId, hex)
+	if status == 1 {
+		for _, target := range getMirrorTargets(deviceId) {
+			updateKeyboardColorHex(target, hex)
+		}
+	}
+	return status
+}
+
+// updateKeyboardColorHex will change color of all keyboard keys from hex string
+func updateKeyboardColorHex(deviceId, hex string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDeviceColorHex"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(hex))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// UpdateKeyColorMap will update colors of multiple keyboard keys at once
+func UpdateKeyColorMap(deviceId string, colors map[int]rgb.Color) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SetKeyColorMap"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(colors))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// UpdateKeyboardGradient will switch keyboard to static gradient between start and end color
+func UpdateKeyboardGradient(deviceId string, start, end rgb.Color) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDeviceGradient"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(start))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(end))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
 // UpdateARGBDevice will process POST request from a client for ARGB 3-pin devices
 func UpdateARGBDevice(deviceId string, portId, deviceType int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateARGBDevice"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -234,7 +322,7 @@ func UpdateARGBDevice(deviceId string, portId, deviceType int) uint8 {
 
 // UpdateExternalHubDeviceType will update a device type connected to an external-LED hub
 func UpdateExternalHubDeviceType(deviceId string, portId, deviceType int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateExternalHubDeviceType"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -257,7 +345,7 @@ func UpdateExternalHubDeviceType(deviceId string, portId, deviceType int) uint8
 
 // UpdatePsuFanMode will update a device fan mode
 func UpdatePsuFanMode(deviceId string, fanMode int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdatePsuFan"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -279,7 +367,7 @@ func UpdatePsuFanMode(deviceId string, fanMode int) uint8 {
 
 // SaveMouseDPI will save mouse DPI values
 func SaveMouseDPI(deviceId string, stages map[int]uint16) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "SaveMouseDPI"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -301,7 +389,7 @@ func SaveMouseDPI(deviceId string, stages map[int]uint16) uint8 {
 
 // SaveMouseZoneColors will save mouse zone colors
 func SaveMouseZoneColors(deviceId string, dpi rgb.Color, zones map[int]rgb.Color) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "SaveMouseZoneColors"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -324,7 +412,7 @@ func SaveMouseZoneColors(deviceId string, dpi rgb.Color, zones map[int]rgb.Color
 
 // SaveMouseDpiColors will save mouse DPI colors
 func SaveMouseDpiColors(deviceId string, dpi rgb.Color, zones map[int]rgb.Color) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "SaveMouseDpiColors"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -347,7 +435,7 @@ func SaveMouseDpiColors(deviceId string, dpi rgb.Color, zones map[int]rgb.Color)
 
 // UpdateExternalHubDeviceAmount will update a device amount connected to an external-LED hub
 func UpdateExternalHubDeviceAmount(deviceId string, portId, deviceType int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateExternalHubDeviceAmount"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -377,7 +465,7 @@ func UpdateDeviceMetrics() {
 	metrics.PopulateStorage()
 
 	// Devices
-	for _, device := range devices {
+	for _, device := range activeDevices() {
 		if device.ProductType == productTypeLinkHub ||
 			device.ProductType == productTypeCC ||
 			device.ProductType == productTypeElite ||
@@ -397,7 +485,7 @@ func UpdateDeviceMetrics() {
 
 // SaveDeviceProfile will save keyboard profile
 func SaveDeviceProfile(deviceId, profileName string, new bool) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "SaveDeviceProfile"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -420,7 +508,7 @@ func SaveDeviceProfile(deviceId, profileName string, new bool) uint8 {
 
 // ChangeKeyboardLayout will change keyboard layout
 func ChangeKeyboardLayout(deviceId, layout string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "ChangeKeyboardLayout"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -442,7 +530,7 @@ func ChangeKeyboardLayout(deviceId, layout string) uint8 {
 
 // ChangeKeyboardControlDial will change keyboard control dial function
 func ChangeKeyboardControlDial(deviceId string, controlDial int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateControlDial"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -464,7 +552,7 @@ func ChangeKeyboardControlDial(deviceId string, controlDial int) uint8 {
 
 // ChangeDeviceSleepMode will change device sleep mode
 func ChangeDeviceSleepMode(deviceId string, sleepMode int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateSleepTimer"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -484,17 +572,738 @@ func ChangeDeviceSleepMode(deviceId string, sleepMode int) uint8 {
 	return 0
 }
 
-// ChangeKeyboardProfile will change keyboard profile
-func ChangeKeyboardProfile(deviceId, profileName string) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "UpdateKeyboardProfile"
+// ChangeKeyboardProfile will change keyboard profile
+func ChangeKeyboardProfile(deviceId, profileName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateKeyboardProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// DeleteKeyboardProfile will save keyboard profile
+func DeleteKeyboardProfile(deviceId, profileName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "DeleteKeyboardProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// RenameKeyboardProfile will rename keyboard profile
+func RenameKeyboardProfile(deviceId, oldName, newName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "RenameKeyboardProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(oldName))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(newName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// CloneKeyboardProfile will create a copy of keyboard profile under a new name
+func CloneKeyboardProfile(deviceId, source, newName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "CloneKeyboardProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(source))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(newName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeAppBindings will change keyboard profiles activated by running applications
+func ChangeAppBindings(deviceId string, bindings map[string]string, fallback string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateAppBindings"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(bindings))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(fallback))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ResetDeviceProfile will reset active device profile to default values
+func ResetDeviceProfile(deviceId string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ResetDeviceProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ReinitLeds will re-initialize device LEDs and restore device RGB
+func ReinitLeds(deviceId string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ReinitLeds"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// IdentifyDevice will flash device LEDs to identify a physical device
+func IdentifyDevice(deviceId string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "IdentifyDevice"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// SaveUserProfile will save new device user profile
+func SaveUserProfile(deviceId, profileName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SaveUserProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ImportUserProfile will import device user profile from JSON data
+func ImportUserProfile(deviceId, profileName string, data []byte) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ImportUserProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(data))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ExportUserProfile will export device user profile as JSON data
+func ExportUserProfile(deviceId string) ([]byte, string, error) {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ExportUserProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil, "", fmt.Errorf("method is not supported for this device type")
+		} else {
+			results := method.Call(nil)
+			if len(results) > 2 {
+				if err, ok := results[2].Interface().(error); ok && err != nil {
+					return nil, "", err
+				}
+				return results[0].Bytes(), results[1].String(), nil
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("non-existing device")
+}
+
+// DumpDiagnostics will return device diagnostics as JSON data
+func DumpDiagnostics(deviceId string) ([]byte, error) {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "DumpDiagnostics"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil, fmt.Errorf("method is not supported for this device type")
+		} else {
+			results := method.Call(nil)
+			if len(results) > 1 {
+				if err, ok := results[1].Interface().(error); ok && err != nil {
+					return nil, err
+				}
+				return results[0].Bytes(), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("non-existing device")
+}
+
+// ChangeTemperatureRange will change device temperature range used by temperature RGB modes
+func ChangeTemperatureRange(deviceId string, minTemp, maxTemp float64) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateTemperatureRange"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(minTemp))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(maxTemp))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeRgbIntensity will change color saturation of generated RGB effect
+func ChangeRgbIntensity(deviceId, profile string, intensity float64) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateRgbIntensity"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profile))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(intensity))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeIdleDimming will change device inactivity timeout and brightness level of idle device
+func ChangeIdleDimming(deviceId string, timeout int, level uint16) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateIdleDimming"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(timeout))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(level))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeNightMode will change device night mode schedule
+func ChangeNightMode(deviceId string, enabled bool, start, end string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateNightMode"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(enabled))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(start))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(end))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeColorOrder will change device color byte order
+func ChangeColorOrder(deviceId, order string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateColorOrder"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(order))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeRgbFrameDelay will change delay between device RGB frames
+func ChangeRgbFrameDelay(deviceId string, frameDelay int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateRgbFrameDelay"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(frameDelay))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialVolumeStep will change keyboard control dial volume step
+func ChangeDialVolumeStep(deviceId string, step int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialVolumeStep"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(step))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialAcceleration will change keyboard brightness dial acceleration factor
+func ChangeDialAcceleration(deviceId string, factor int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialAcceleration"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(factor))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// SetAnimatedKeys will set keyboard keys animated by RGB effects
+func SetAnimatedKeys(deviceId string, keyIds []int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SetAnimatedKeys"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(keyIds))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeWaveDirection will change keyboard wave RGB mode direction and origin key
+func ChangeWaveDirection(deviceId string, direction, originKeyId int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateWaveDirection"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(direction))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(originKeyId))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialLongPress will change keyboard control dial long press action
+func ChangeDialLongPress(deviceId string, action int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialLongPress"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(action))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialRotateAction will change keyboard control dial rotate action
+func ChangeDialRotateAction(deviceId string, action int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialRotateAction"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(action))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialClickAction will change keyboard control dial click action
+func ChangeDialClickAction(deviceId string, action int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialClickAction"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(action))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeDialInvert will change keyboard control dial rotation direction
+func ChangeDialInvert(deviceId string, invert bool) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDialInvert"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(invert))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeRowBrightness will change brightness of a single keyboard row
+func ChangeRowBrightness(deviceId string, rowId int, value uint8) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateRowBrightness"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(rowId))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeGpuSensor will change GPU sensor used for device GPU temperature
+func ChangeGpuSensor(deviceId, sensor string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateGpuSensor"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(sensor))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeProfileMetadata will change description and tags of active user profile
+func ChangeProfileMetadata(deviceId, description string, tags []string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateProfileMetadata"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(description))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(tags))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ChangeBootProfile will change user profile activated on device startup
+func ChangeBootProfile(deviceId string, profileName string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateBootProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// UpdateDevicePosition will change device position
+func UpdateDevicePosition(deviceId string, position, direction int) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "UpdateDevicePosition"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(position))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(direction))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// ScheduleDeviceBrightness will change device brightness level based on scheduler
+func ScheduleDeviceBrightness(mode uint8) {
+	for _, device := range GetDevices() {
+		methodName := "ChangeDeviceBrightnessValue"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			continue
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(mode))
+			method.Call(reflectArgs)
+		}
+	}
+}
+
+// StopAllRGB will turn off LEDs on every device that supports it. LEDs are restored on the next profile change
+func StopAllRGB() uint8 {
+	var status uint8 = 0
+	for _, device := range GetDevices() {
+		methodName := "TurnOffLeds"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			continue // Device doesn't support LED kill switch
+		}
+
+		results := method.Call(nil)
+		if len(results) > 0 && results[0].Uint() == 1 {
+			status = 1
+		}
+	}
+	return status
+}
+
+// ChangeDeviceBrightness will change device brightness level
+func ChangeDeviceBrightness(deviceId string, value uint8) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ChangeDeviceBrightness"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
 			return 0
 		} else {
 			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
 			results := method.Call(reflectArgs)
 			if len(results) > 0 {
 				val := results[0]
@@ -506,17 +1315,17 @@ func ChangeKeyboardProfile(deviceId, profileName string) uint8 {
 	return 0
 }
 
-// DeleteKeyboardProfile will save keyboard profile
-func DeleteKeyboardProfile(deviceId, profileName string) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "DeleteKeyboardProfile"
+// ChangeDeviceBrightnessGradual will change device brightness level via defined number from 0-100
+func ChangeDeviceBrightnessGradual(deviceId string, value uint8) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ChangeDeviceBrightnessValue"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
 			return 0
 		} else {
 			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
 			results := method.Call(reflectArgs)
 			if len(results) > 0 {
 				val := results[0]
@@ -528,17 +1337,17 @@ func DeleteKeyboardProfile(deviceId, profileName string) uint8 {
 	return 0
 }
 
-// SaveUserProfile will save new device user profile
-func SaveUserProfile(deviceId, profileName string) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "SaveUserProfile"
+// SetBrightnessPercent will change device brightness level via percentage from 0-100
+func SetBrightnessPercent(deviceId string, value uint8) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SetBrightnessPercent"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
 			return 0
 		} else {
 			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(profileName))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
 			results := method.Call(reflectArgs)
 			if len(results) > 0 {
 				val := results[0]
@@ -550,18 +1359,17 @@ func SaveUserProfile(deviceId, profileName string) uint8 {
 	return 0
 }
 
-// UpdateDevicePosition will change device position
-func UpdateDevicePosition(deviceId string, position, direction int) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "UpdateDevicePosition"
+// SetBrightnessLevel will change device brightness level with full hardware resolution, 0-1000
+func SetBrightnessLevel(deviceId string, level uint16) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SetBrightnessLevel"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
 			return 0
 		} else {
 			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(position))
-			reflectArgs = append(reflectArgs, reflect.ValueOf(direction))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(level))
 			results := method.Call(reflectArgs)
 			if len(results) > 0 {
 				val := results[0]
@@ -573,56 +1381,35 @@ func UpdateDevicePosition(deviceId string, position, direction int) uint8 {
 	return 0
 }
 
-// ScheduleDeviceBrightness will change device brightness level based on scheduler
-func ScheduleDeviceBrightness(mode uint8) {
-	for _, device := range GetDevices() {
-		methodName := "ChangeDeviceBrightnessValue"
-		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
-		if !method.IsValid() {
-			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
-			continue
-		} else {
-			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(mode))
-			method.Call(reflectArgs)
-		}
-	}
-}
-
-// ChangeDeviceBrightness will change device brightness level
-func ChangeDeviceBrightness(deviceId string, value uint8) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "ChangeDeviceBrightness"
+// SelfTest will verify that a device responds to basic commands
+func SelfTest(deviceId string) error {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "SelfTest"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
-			return 0
+			return errors.New("self-test is not supported by this device")
 		} else {
-			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
-			results := method.Call(reflectArgs)
-			if len(results) > 0 {
-				val := results[0]
-				uintResult := val.Uint()
-				return uint8(uintResult)
+			results := method.Call(nil)
+			if len(results) > 0 && !results[0].IsNil() {
+				return results[0].Interface().(error)
 			}
+			return nil
 		}
 	}
-	return 0
+	return errors.New("non-existing device")
 }
 
-// ChangeDeviceBrightnessGradual will change device brightness level via defined number from 0-100
-func ChangeDeviceBrightnessGradual(deviceId string, value uint8) uint8 {
-	if device, ok := devices[deviceId]; ok {
-		methodName := "ChangeDeviceBrightnessValue"
+// ReloadProfiles will reload device profiles from disk
+func ReloadProfiles(deviceId string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ReloadProfiles"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
 			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
 			return 0
 		} else {
-			var reflectArgs []reflect.Value
-			reflectArgs = append(reflectArgs, reflect.ValueOf(value))
-			results := method.Call(reflectArgs)
+			results := method.Call(nil)
 			if len(results) > 0 {
 				val := results[0]
 				uintResult := val.Uint()
@@ -635,7 +1422,7 @@ func ChangeDeviceBrightnessGradual(deviceId string, value uint8) uint8 {
 
 // ChangeUserProfile will change device user profile
 func ChangeUserProfile(deviceId, profileName string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "ChangeDeviceProfile"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -657,7 +1444,7 @@ func ChangeUserProfile(deviceId, profileName string) uint8 {
 
 // UpdateDeviceLcd will update device LCD
 func UpdateDeviceLcd(deviceId string, channelId int, mode uint8) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceLcd"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -680,7 +1467,7 @@ func UpdateDeviceLcd(deviceId string, channelId int, mode uint8) uint8 {
 
 // ChangeDeviceLcd will change device LCD
 func ChangeDeviceLcd(deviceId string, channelId int, lcdSerial string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "ChangeDeviceLcd"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -703,7 +1490,7 @@ func ChangeDeviceLcd(deviceId string, channelId int, lcdSerial string) uint8 {
 
 // UpdateDeviceLcdRotation will update device LCD rotation
 func UpdateDeviceLcdRotation(deviceId string, channelId int, rotation uint8) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceLcdRotation"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -726,7 +1513,7 @@ func UpdateDeviceLcdRotation(deviceId string, channelId int, rotation uint8) uin
 
 // UpdateDeviceLcdImage will update device LCD image
 func UpdateDeviceLcdImage(deviceId string, channelId int, image string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceLcdImage"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -749,7 +1536,7 @@ func UpdateDeviceLcdImage(deviceId string, channelId int, image string) uint8 {
 
 // UpdateDeviceLabel will set / update device label
 func UpdateDeviceLabel(deviceId string, channelId int, label string, deviceType int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := ""
 		if deviceType == 0 {
 			methodName = "UpdateDeviceLabel"
@@ -777,7 +1564,7 @@ func UpdateDeviceLabel(deviceId string, channelId int, label string, deviceType
 
 // UpdateSpeedProfile will update device speeds with a given serial number
 func UpdateSpeedProfile(deviceId string, channelId int, profile string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateSpeedProfile"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -800,7 +1587,7 @@ func UpdateSpeedProfile(deviceId string, channelId int, profile string) uint8 {
 
 // UpdateManualSpeed will update device speeds with a given serial number
 func UpdateManualSpeed(deviceId string, channelId int, value uint16) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateDeviceSpeed"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -823,7 +1610,7 @@ func UpdateManualSpeed(deviceId string, channelId int, value uint16) uint8 {
 
 // UpdateRgbStrip will update device RGB strip
 func UpdateRgbStrip(deviceId string, channelId int, stripId int) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateExternalAdapter"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -844,9 +1631,20 @@ func UpdateRgbStrip(deviceId string, channelId int, stripId int) uint8 {
 	return 0
 }
 
-// UpdateRgbProfile will update device RGB profile
+// UpdateRgbProfile will update device RGB profile. Profile is mirrored to mirror targets
 func UpdateRgbProfile(deviceId string, channelId int, profile string) uint8 {
-	if device, ok := devices[deviceId]; ok {
+	status := updateRgbProfile(deviceId, channelId, profile)
+	if status == 1 {
+		for _, target := range getMirrorTargets(deviceId) {
+			updateRgbProfile(target, -1, profile) // Mirrored to all channels of a target
+		}
+	}
+	return status
+}
+
+// updateRgbProfile will update device RGB profile
+func updateRgbProfile(deviceId string, channelId int, profile string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
 		methodName := "UpdateRgbProfile"
 		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
 		if !method.IsValid() {
@@ -867,9 +1665,51 @@ func UpdateRgbProfile(deviceId string, channelId int, profile string) uint8 {
 	return 0
 }
 
+// PreviewRgbProfile will start device RGB mode without saving it
+func PreviewRgbProfile(deviceId string, profile string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "PreviewRgbProfile"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(profile))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// CancelRgbPreview will stop device RGB mode preview and restore saved RGB mode
+func CancelRgbPreview(deviceId string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "CancelPreview"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
 // ResetSpeedProfiles will reset the speed profile on each available device
 func ResetSpeedProfiles(profile string) {
-	for _, device := range devices {
+	for _, device := range activeDevices() {
 		if device.ProductType == productTypeLinkHub ||
 			device.ProductType == productTypeCC ||
 			device.ProductType == productTypeCCXT {
@@ -887,15 +1727,159 @@ func ResetSpeedProfiles(profile string) {
 	}
 }
 
-// GetDevices will return all available devices
+// GetRgbModes will return a list of RGB modes supported by the device
+func GetRgbModes(deviceId string) interface{} {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "GetRgbModes"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				return results[0].Interface()
+			}
+		}
+	}
+	return nil
+}
+
+// GetDeviceStatus will return current status of the device
+func GetDeviceStatus(deviceId string) interface{} {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "GetDeviceStatus"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				return results[0].Interface()
+			}
+		}
+	}
+	return nil
+}
+
+// GetKeyColors will return current per-key colors of the keyboard
+func GetKeyColors(deviceId string) interface{} {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "GetKeyColors"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 {
+				return results[0].Interface()
+			}
+		}
+	}
+	return nil
+}
+
+// GetLayoutGeometry will return keyboard layout geometry of the device
+func GetLayoutGeometry(deviceId string) interface{} {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "GetLayoutGeometry"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 && !results[0].IsNil() {
+				return results[0].Interface()
+			}
+		}
+	}
+	return nil
+}
+
+// ListDevices will return components and HID interfaces of the device
+func ListDevices(deviceId string) interface{} {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "ListDevices"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		} else {
+			results := method.Call(nil)
+			if len(results) > 0 && !results[0].IsNil() {
+				return results[0].Interface()
+			}
+		}
+	}
+	return nil
+}
+
+// GetKeyboardLayouts will return current and available keyboard layouts of the device
+func GetKeyboardLayouts(deviceId string) *KeyboardLayouts {
+	if device, ok := lookupDevice(deviceId); ok {
+		instance := reflect.ValueOf(GetDevice(device.Serial))
+		current := instance.MethodByName("GetCurrentLayout")
+		available := instance.MethodByName("GetAvailableLayouts")
+		if !current.IsValid() || !available.IsValid() {
+			logger.Log(logger.Fields{"method": "GetAvailableLayouts"}).Warn("Method not found or method is not supported for this device type")
+			return nil
+		}
+
+		layouts := &KeyboardLayouts{}
+		if results := current.Call(nil); len(results) > 0 {
+			layouts.Current = results[0].String()
+		}
+		if results := available.Call(nil); len(results) > 0 {
+			layouts.Available, _ = results[0].Interface().([]string)
+		}
+		return layouts
+	}
+	return nil
+}
+
+// RawTransfer will send raw packet to a device and return device output. Device has to run in debug mode
+func RawTransfer(deviceId string, endpoint, payload []byte, command byte) ([]byte, error) {
+	if device, ok := lookupDevice(deviceId); ok {
+		methodName := "RawTransfer"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return nil, errors.New("raw transfer is not supported by this device")
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(endpoint))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(payload))
+			reflectArgs = append(reflectArgs, reflect.ValueOf(command))
+			results := method.Call(reflectArgs)
+			if len(results) > 1 {
+				if !results[1].IsNil() {
+					return nil, results[1].Interface().(error)
+				}
+				return results[0].Interface().([]byte), nil
+			}
+		}
+	}
+	return nil, errors.New("non-existing device")
+}
+
+// GetDevices will return a copy of all available devices
 func GetDevices() map[string]*Device {
-	return devices
+	mutexDevices.RLock()
+	defer mutexDevices.RUnlock()
+	list := make(map[string]*Device, len(devices))
+	for serial, device := range devices {
+		list[serial] = device
+	}
+	return list
 }
 
 // GetTemperatureProbes will return a list of temperature probes
 func GetTemperatureProbes() interface{} {
 	var probes []interface{}
-	for _, device := range devices {
+	for _, device := range activeDevices() {
 		if device.ProductType == productTypeLinkHub ||
 			device.ProductType == productTypeCC ||
 			device.ProductType == productTypeCCXT ||
@@ -918,9 +1902,113 @@ func GetTemperatureProbes() interface{} {
 	return probes
 }
 
+// ChangeMirrorTarget will change device mirroring RGB profile and color changes of a given device.
+// Target that would create a mirror loop is rejected
+func ChangeMirrorTarget(deviceId, target string) uint8 {
+	if device, ok := lookupDevice(deviceId); ok {
+		if len(target) > 0 {
+			if _, ok := lookupDevice(target); !ok {
+				return 2
+			}
+			if target == deviceId || slices.Contains(getMirrorTargets(target), deviceId) {
+				return 3
+			}
+		}
+
+		methodName := "UpdateMirrorTarget"
+		method := reflect.ValueOf(GetDevice(device.Serial)).MethodByName(methodName)
+		if !method.IsValid() {
+			logger.Log(logger.Fields{"method": methodName}).Warn("Method not found or method is not supported for this device type")
+			return 0
+		} else {
+			var reflectArgs []reflect.Value
+			reflectArgs = append(reflectArgs, reflect.ValueOf(target))
+			results := method.Call(reflectArgs)
+			if len(results) > 0 {
+				val := results[0]
+				uintResult := val.Uint()
+				return uint8(uintResult)
+			}
+		}
+	}
+	return 0
+}
+
+// getMirrorTargets will return serials of devices mirroring RGB changes of a given device, following the whole chain.
+// Chain is stopped on a device that is already mirrored, so a loop created by profile files can't run forever
+func getMirrorTargets(deviceId string) []string {
+	var targets []string
+	visited := map[string]bool{deviceId: true}
+	current := deviceId
+	for {
+		if _, ok := lookupDevice(current); !ok {
+			break
+		}
+
+		method := reflect.ValueOf(GetDevice(current)).MethodByName("GetMirrorTarget")
+		if !method.IsValid() {
+			break
+		}
+
+		results := method.Call(nil)
+		if len(results) == 0 || len(results[0].String()) == 0 {
+			break
+		}
+
+		target := results[0].String()
+		if visited[target] {
+			logger.Log(logger.Fields{"serial": current, "target": target}).Warn("RGB mirror loop detected")
+			break
+		}
+		if _, ok := lookupDevice(target); !ok {
+			break // Target is not connected
+		}
+		visited[target] = true
+		targets = append(targets, target)
+		current = target
+	}
+	return targets
+}
+
+// addDevice will add initialized device to the list of active devices
+func addDevice(deviceId string, device *Device) {
+	mutexDevices.Lock()
+	defer mutexDevices.Unlock()
+	devices[deviceId] = device
+}
+
+// removeDevice will remove unplugged device from the list of active devices
+func removeDevice(serial string) {
+	mutexDevices.Lock()
+	defer mutexDevices.Unlock()
+	if _, ok := devices[serial]; ok {
+		delete(devices, serial)
+		logger.Log(logger.Fields{"serial": serial}).Info("Device removed")
+	}
+}
+
+// lookupDevice will return active device by device serial
+func lookupDevice(deviceId string) (*Device, bool) {
+	mutexDevices.RLock()
+	defer mutexDevices.RUnlock()
+	device, ok := devices[deviceId]
+	return device, ok
+}
+
+// activeDevices will return a snapshot of active devices, safe to iterate while devices are added or removed
+func activeDevices() []*Device {
+	mutexDevices.RLock()
+	defer mutexDevices.RUnlock()
+	list := make([]*Device, 0, len(devices))
+	for _, device := range devices {
+		list = append(list, device)
+	}
+	return list
+}
+
 // GetDevice will return a device by device serial
 func GetDevice(deviceId string) interface{} {
-	if device, ok := devices[deviceId]; ok {
+	if device, ok := lookupDevice(deviceId); ok {
 		return device.Instance
 	}
 	return nil
@@ -1015,15 +2103,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeLinkHub,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, key)
 			}
 
@@ -1034,15 +2122,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeCC,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, key)
 			}
 		case 3114: // CORSAIR iCUE COMMANDER CORE XT
@@ -1052,15 +2140,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeCCXT,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, key)
 			}
 		case 3125, 3126, 3127, 3136, 3137, 3104, 3105, 3106, 3095, 3096, 3097:
@@ -1081,15 +2169,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[strconv.Itoa(int(productId))] = &Device{
+					addDevice(strconv.Itoa(int(productId)), &Device{
 						ProductType: productTypeElite,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId)
 			}
 		case 3098: // CORSAIR Lighting Node CORE
@@ -1099,14 +2187,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeLNCore,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 3083: // CORSAIR Lighting Node Pro
@@ -1116,14 +2204,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeLnPro,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 3088: // Corsair Commander Pro
@@ -1133,15 +2221,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeCPro,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, key)
 			}
 		case 3138: // CORSAIR XC7 ELITE LCD CPU Water Block
@@ -1151,15 +2239,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeXC7,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-device.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, key)
 			}
 		case 7127: // K65 Pro Mini
@@ -1169,14 +2257,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK65PM,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7165: // K70 CORE RGB
@@ -1186,14 +2274,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK70Core,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7166: // K55 CORE RGB
@@ -1203,14 +2291,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK55Core,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7110, 7091: // K70 RGB PRO
@@ -1220,14 +2308,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK70Pro,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 11024: // K65 PLUS USB
@@ -1237,14 +2325,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					dev.RegisterUnplugHandler(removeDevice)
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK65Plus,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 11015: // K65 PLUS USB
@@ -1254,14 +2343,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					dev.RegisterUnplugHandler(removeDevice)
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK65PlusW,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7083: // K100 AIR USB
@@ -1271,14 +2361,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK100Air,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7109: // K100 RGB
@@ -1288,28 +2378,28 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeK100,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-keyboard.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7132, 7078, 11008: // Corsair SLIPSTREAM WIRELESS USB Receiver
 			{
 				go func(vendorId, productId uint16, key string) {
 					dev := slipstream.Init(vendorId, productId, key)
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeIronClawRgbW,
 						Product:     "SLIPSTREAM",
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-dongle.svg",
 						Instance:    dev,
-					}
+					})
 					for _, value := range dev.Devices {
 						switch value.ProductId {
 						case 7163:
@@ -1322,14 +2412,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeM55W,
 									Product:     "M55 WIRELESS",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-mouse.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 						case 7131:
@@ -1342,14 +2432,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeScimitarRgbEliteW,
 									Product:     "SCIMITAR RGB ELITE",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-mouse.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 						case 7096: // NIGHTSABRE
@@ -1362,14 +2452,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeNightsabreW,
 									Product:     "NIGHTSABRE",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-mouse.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 						case 7083: // K100 AIR WIRELESS
@@ -1382,14 +2472,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeK100AirW,
 									Product:     "K100 AIR",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-keyboard.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 						case 6988: // IRONCLAW RGB WIRELESS
@@ -1402,14 +2492,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeIronClawRgbW,
 									Product:     "IRONCLAW RGB",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-mouse.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 						default:
@@ -1425,14 +2515,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeST100,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-headphone.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7067: // Corsair MM700 RGB Gaming Mousepad
@@ -1442,14 +2532,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeMM700,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mousepad.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 3107: // Corsair iCUE LT100 Smart Lighting Tower
@@ -1459,14 +2549,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeLT100,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-rgb.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key, productPath)
 			}
 		case 7198, 7203, 7199, 7173, 7174, 7175, 7176, 7181, 7180:
@@ -1485,15 +2575,15 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypePSUHid,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-psu.svg",
 						Instance:    dev,
-					}
-					devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+						GetDevice:   dev,
+					})
 				}(vendorId, productId, productPath)
 			}
 		case 7059: // Corsair KATAR PRO Gaming Mouse
@@ -1503,14 +2593,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeKatarPro,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7005: // Corsair IRONCLAW RGB Gaming Mouse
@@ -1520,14 +2610,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeIronClawRgb,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 6988: // Corsair IRONCLAW RGB WIRELESS Gaming Mouse
@@ -1537,14 +2627,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeIronClawRgbWU,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7096: // Corsair NIGHTSABRE WIRELESS Mouse
@@ -1554,14 +2644,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeNightsabreWU,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7139: // CORSAIR SCIMITAR RGB ELITE
@@ -1571,14 +2661,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeScimitarRgbElite,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7131: // CORSAIR SCIMITAR RGB ELITE WIRELESS
@@ -1588,14 +2678,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeScimitarRgbEliteWU,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 11011: // CORSAIR M55 Gaming Mouse
@@ -1605,14 +2695,14 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeM55,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7024: // CORSAIR M55 RGB PRO Gaming Mouse
@@ -1622,21 +2712,21 @@ func Init() {
 					if dev == nil {
 						return
 					}
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeM55RgbPro,
 						Product:     dev.Product,
 						Serial:      dev.Serial,
 						Firmware:    dev.Firmware,
 						Image:       "icon-mouse.svg",
 						Instance:    dev,
-					}
+					})
 				}(vendorId, productId, key)
 			}
 		case 7060: // CORSAIR KATAR PRO Wireless Gaming Dongle
 			{
 				go func(vendorId, productId uint16, key string) {
 					dev := dongle.Init(vendorId, productId, key)
-					devices[dev.Serial] = &Device{
+					addDevice(dev.Serial, &Device{
 						ProductType: productTypeIronClawRgbW,
 						Product:     "SLIPSTREAM",
 						Serial:      dev.Serial,
@@ -1644,7 +2734,7 @@ func Init() {
 						Image:       "icon-dongle.svg",
 						Instance:    dev,
 						Hidden:      true,
-					}
+					})
 
 					for _, value := range dev.Devices {
 						switch value.ProductId {
@@ -1658,14 +2748,14 @@ func Init() {
 									value.Endpoint,
 									value.Serial,
 								)
-								devices[d.Serial] = &Device{
+								addDevice(d.Serial, &Device{
 									ProductType: productTypeKatarProW,
 									Product:     "KATAR PRO WIRELESS",
 									Serial:      d.Serial,
 									Firmware:    d.Firmware,
 									Image:       "icon-mouse.svg",
 									Instance:    d,
-								}
+								})
 								dev.AddPairedDevice(value.ProductId, d)
 							}
 							break
@@ -1678,15 +2768,15 @@ func Init() {
 				go func(serialId string) {
 					dev := memory.Init(serialId, "Memory")
 					if dev != nil {
-						devices[dev.Serial] = &Device{
+						addDevice(dev.Serial, &Device{
 							ProductType: productTypeMemory,
 							Product:     dev.Product,
 							Serial:      dev.Serial,
 							Firmware:    "0",
 							Image:       "icon-ram.svg",
 							Instance:    dev,
-						}
-						devices[dev.Serial].GetDevice = GetDevice(dev.Serial)
+							GetDevice:   dev,
+						})
 					}
 				}(key)
 			}
@@ -0,0 +1,123 @@
+// Package hidtest contains a fake HID device and fixture helpers shared by device tests
+package hidtest
+
+import (
+	"encoding/json"
+	"github.com/sstallion/go-hid"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Device is a HID device used by tests. Written packets are recorded and every read returns the next queued response
+type Device struct {
+	mutex     sync.Mutex
+	product   string
+	written   [][]byte
+	responses [][]byte
+	closed    bool
+}
+
+// New will create a fake HID device with given product name and queued read responses
+func New(product string, responses ...[]byte) *Device {
+	return &Device{product: product, responses: responses}
+}
+
+func (f *Device) Write(b []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.written = append(f.written, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func (f *Device) Read(b []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.responses) > 0 {
+		n := copy(b, f.responses[0])
+		f.responses = f.responses[1:]
+		return n, nil
+	}
+	return len(b), nil
+}
+
+func (f *Device) GetMfrStr() (string, error)     { return "Corsair", nil }
+func (f *Device) GetProductStr() (string, error) { return f.product, nil }
+func (f *Device) GetSerialNbr() (string, error)  { return "TESTSERIAL", nil }
+
+func (f *Device) GetDeviceInfo() (*hid.DeviceInfo, error) {
+	return &hid.DeviceInfo{ProductStr: f.product, InterfaceNbr: 1, UsagePage: 0xff42}, nil
+}
+
+func (f *Device) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.closed = true
+	return nil
+}
+
+// Closed will return true if device was closed
+func (f *Device) Closed() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.closed
+}
+
+// Packets will return all packets written so far
+func (f *Device) Packets() [][]byte {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([][]byte(nil), f.written...)
+}
+
+// Count will return number of written packets accepted by match
+func (f *Device) Count(match func(packet []byte) bool) int {
+	count := 0
+	for _, packet := range f.Packets() {
+		if match(packet) {
+			count++
+		}
+	}
+	return count
+}
+
+// WaitFor will wait until at least count written packets are accepted by match
+func (f *Device) WaitFor(match func(packet []byte) bool, count int) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if f.Count(match) >= count {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+// LoadJson will decode repository database file into v
+func LoadJson(t testing.TB, name string, v any) {
+	t.Helper()
+	_, source, _, _ := runtime.Caller(0)
+	location := filepath.Join(filepath.Dir(source), "../../../../database", name)
+
+	file, err := os.Open(location)
+	if err != nil {
+		t.Fatalf("unable to open %s: %v", name, err)
+	}
+	defer file.Close()
+	if err = json.NewDecoder(file).Decode(v); err != nil {
+		t.Fatalf("unable to decode %s: %v", name, err)
+	}
+}
+
+// WorkingDirectory will create a temporary working directory with profile folder, so tests never write to repository database
+func WorkingDirectory(t testing.TB) string {
+	t.Helper()
+	pwd := t.TempDir()
+	if err := os.MkdirAll(pwd+"/database/profiles", 0755); err != nil {
+		t.Fatalf("unable to create profile folder: %v", err)
+	}
+	return pwd
+}
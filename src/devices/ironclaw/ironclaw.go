@@ -309,8 +309,8 @@ func (d *Device) getDeviceFirmware() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // setAutoRefresh will refresh device data
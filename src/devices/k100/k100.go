@@ -90,7 +90,6 @@ var (
 	authRefreshChan         = make(chan bool)
 	keepAliveChan           = make(chan bool)
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 1024
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
@@ -98,7 +97,6 @@ var (
 	maxBufferSizePerRequest = 1021
 	colorPacketLength       = 581
 	keyboardKey             = "k100-default"
-	defaultLayout           = "k100-default-US"
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
@@ -315,9 +313,9 @@ func (d *Device) initLeds() {
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -337,13 +335,14 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
 		deviceProfile.BrightnessLevel = 1000
 		deviceProfile.Brightness = 0
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 		deviceProfile.ControlDial = 1
 	} else {
 		if len(d.DeviceProfile.Layout) == 0 {
@@ -525,8 +524,8 @@ func (d *Device) setAutoRefresh() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // UpdateDeviceLabel will set / update device label
@@ -84,7 +84,6 @@ var (
 	authRefreshChan         = make(chan bool)
 	keepAliveChan           = make(chan bool)
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 1024
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
@@ -92,7 +91,6 @@ var (
 	maxBufferSizePerRequest = 1021
 	colorPacketLength       = 413
 	keyboardKey             = "k100air-default"
-	defaultLayout           = "k100air-default-US"
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
@@ -303,9 +301,9 @@ func (d *Device) initLeds() {
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -325,12 +323,13 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
 		deviceProfile.BrightnessLevel = 1000
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 	} else {
 		if len(d.DeviceProfile.Layout) == 0 {
 			deviceProfile.Layout = "US"
@@ -510,8 +509,8 @@ func (d *Device) setAutoRefresh() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // UpdateDeviceLabel will set / update device label
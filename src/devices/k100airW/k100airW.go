@@ -93,14 +93,12 @@ var (
 	cmdWriteColor           = []byte{0x06, 0x01}
 	cmdSleep                = []byte{0x01, 0x0e, 0x00}
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 64
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
 	headerWriteSize         = 4
 	maxBufferSizePerRequest = 61
 	keyboardKey             = "k100airW-default"
-	defaultLayout           = "k100airW-default-US"
 )
 
 func Init(vendorId, slipstreamId, productId uint16, dev *hid.Device, endpoint byte, serial string) *Device {
@@ -360,9 +358,9 @@ func (d *Device) initLeds() {
 		logger.Log(logger.Fields{"error": err}).Error("Unable to change device mode")
 	}
 
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -382,11 +380,12 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 		deviceProfile.ControlDial = 1
 		deviceProfile.BrightnessLevel = 1000
 		deviceProfile.SleepMode = 15
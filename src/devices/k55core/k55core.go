@@ -84,7 +84,6 @@ var (
 	authRefreshChan         = make(chan bool)
 	keepAliveChan           = make(chan bool)
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 64
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
@@ -92,7 +91,6 @@ var (
 	maxBufferSizePerRequest = 61
 	colorPacketLength       = 35
 	keyboardKey             = "k55core-default"
-	defaultLayout           = "k55core-default-US"
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
@@ -302,9 +300,9 @@ func (d *Device) initLeds() {
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -326,11 +324,12 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 	} else {
 		if d.DeviceProfile.BrightnessSlider == nil {
 			deviceProfile.BrightnessSlider = &defaultBrightness
@@ -516,8 +515,8 @@ func (d *Device) setAutoRefresh() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // UpdateDeviceLabel will set / update device label
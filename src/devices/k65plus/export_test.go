@@ -0,0 +1,6 @@
+package k65plus
+
+// newForTest will create a device backed by a fake HID handle, without opening HID or loading profiles
+func newForTest(fake hidDevice) *Device {
+	return newDevice(fake, 6940, 11024, "", false)
+}
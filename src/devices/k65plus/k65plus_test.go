@@ -0,0 +1,366 @@
+package k65plus
+
+import (
+	"OpenLinkHub/src/common"
+	"OpenLinkHub/src/devices/internal/hidtest"
+	"OpenLinkHub/src/keyboards"
+	"OpenLinkHub/src/rgb"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDevice will create a device with default keyboard profile and RGB profiles from repository database
+func newTestDevice(t *testing.T) (*Device, *hidtest.Device) {
+	t.Helper()
+	keyboard := &keyboards.Keyboard{}
+	hidtest.LoadJson(t, "keyboard/k65plus.json", keyboard)
+	profiles := &rgb.RGB{}
+	hidtest.LoadJson(t, "rgb.json", profiles)
+
+	// Profiles are saved to a temporary folder
+	pwd = hidtest.WorkingDirectory(t)
+
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+	d.Serial = "TESTSERIAL"
+	d.Rgb = profiles
+	d.DeviceProfile = &DeviceProfile{
+		Serial:           d.Serial,
+		RGBProfile:       "keyboard",
+		Label:            "Keyboard",
+		Active:           true,
+		Profile:          "default",
+		Profiles:         []string{"default"},
+		Keyboards:        map[string]*keyboards.Keyboard{"default": keyboard},
+		Layout:           keyboard.Layout,
+		RGBFrameDelay:    defaultFrameDelay,
+		BrightnessLevel:  1000,
+		DialVolumeStep:   defaultVolumeStep,
+		DialAcceleration: defaultDialAcceleration,
+	}
+	d.UserProfiles = map[string]*DeviceProfile{"default": d.DeviceProfile}
+	return d, fake
+}
+
+// endpoint will match packets sent to given endpoint
+func endpoint(endpoint []byte) func(packet []byte) bool {
+	return func(packet []byte) bool {
+		return bytes.Equal(packet[headerSize:headerSize+len(endpoint)], endpoint)
+	}
+}
+
+func TestTransferBufferLayout(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+
+	if _, err := d.transfer(cmdBrightness, []byte{0xe8, 0x03}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	packets := fake.Packets()
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %d", len(packets))
+	}
+
+	packet := packets[0]
+	if len(packet) != bufferSizeWrite {
+		t.Fatalf("expected packet length %d, got %d", bufferSizeWrite, len(packet))
+	}
+	if packet[0] != 0x00 || packet[1] != 0x08 {
+		t.Errorf("unexpected report header % x", packet[:2])
+	}
+	if !bytes.Equal(packet[headerSize:headerSize+len(cmdBrightness)], cmdBrightness) {
+		t.Errorf("unexpected endpoint % x", packet[headerSize:headerSize+len(cmdBrightness)])
+	}
+	if !bytes.Equal(packet[headerSize+len(cmdBrightness):headerSize+len(cmdBrightness)+2], []byte{0xe8, 0x03}) {
+		t.Errorf("unexpected payload % x", packet[headerSize+len(cmdBrightness):])
+	}
+}
+
+func TestWriteColorChunks(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+
+	d.writeColor(make([]byte, colorPacketLength))
+
+	packets := fake.Packets()
+	frameLength := headerWriteSize + len(dataTypeSetColor) + colorPacketLength
+	expected := (frameLength + maxBufferSizePerRequest - 1) / maxBufferSizePerRequest
+	if len(packets) != expected {
+		t.Fatalf("expected %d chunks, got %d", expected, len(packets))
+	}
+
+	first := packets[0]
+	if !bytes.Equal(first[headerSize:headerSize+len(cmdWriteColor)], cmdWriteColor) {
+		t.Errorf("first chunk endpoint is % x", first[headerSize:headerSize+len(cmdWriteColor)])
+	}
+
+	header := first[headerSize+len(cmdWriteColor):]
+	if length := binary.LittleEndian.Uint16(header[0:2]); int(length) != colorPacketLength+2 {
+		t.Errorf("expected frame length %d, got %d", colorPacketLength+2, length)
+	}
+	if !bytes.Equal(header[headerWriteSize:headerWriteSize+len(dataTypeSetColor)], dataTypeSetColor) {
+		t.Errorf("unexpected data type % x", header[headerWriteSize:headerWriteSize+len(dataTypeSetColor)])
+	}
+
+	for i, packet := range packets[1:] {
+		if !bytes.Equal(packet[headerSize:headerSize+len(dataTypeSubColor)], dataTypeSubColor) {
+			t.Errorf("chunk %d endpoint is % x", i+1, packet[headerSize:headerSize+len(dataTypeSubColor)])
+		}
+	}
+}
+
+func TestGetDeviceFirmware(t *testing.T) {
+	response := make([]byte, bufferSize)
+	response[3] = 1
+	response[4] = 2
+	binary.LittleEndian.PutUint16(response[5:7], 34)
+
+	fake := hidtest.New("K65 Plus", response)
+	d := newForTest(fake)
+	if err := d.getDeviceFirmware(); err != nil {
+		t.Fatalf("getDeviceFirmware failed: %v", err)
+	}
+	if d.Firmware != "1.2.34" {
+		t.Errorf("expected firmware 1.2.34, got %s", d.Firmware)
+	}
+}
+
+func TestGetDeviceFirmwareEmptyResponse(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+	if err := d.getDeviceFirmware(); err != nil {
+		t.Fatalf("getDeviceFirmware failed: %v", err)
+	}
+	if d.Firmware != firmwareUnknown {
+		t.Errorf("expected firmware %s, got %s", firmwareUnknown, d.Firmware)
+	}
+	if len(fake.Packets()) != firmwareReadAttempts {
+		t.Errorf("expected %d firmware reads, got %d", firmwareReadAttempts, len(fake.Packets()))
+	}
+}
+
+func TestRenderAllModes(t *testing.T) {
+	for mode := range rgbModes {
+		t.Run(mode, func(t *testing.T) {
+			d, fake := newTestDevice(t)
+			d.DeviceProfile.RGBProfile = mode
+			defer d.stopRgb()
+
+			d.setDeviceColor()
+
+			// Every mode starts with a reset frame, off mode keeps colors reset
+			frames := 2
+			if mode == "off" {
+				frames = 1
+			}
+			if !fake.WaitFor(endpoint(cmdWriteColor), frames) {
+				t.Fatalf("mode %s wrote %d frames, expected at least %d", mode, fake.Count(endpoint(cmdWriteColor)), frames)
+			}
+		})
+	}
+}
+
+func TestWriteColorUndersizedBuffer(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+
+	for length := 0; length < 6; length++ {
+		d.writeColor(make([]byte, length)) // Must not panic
+	}
+	if packets := fake.Packets(); len(packets) != 0 {
+		t.Errorf("undersized buffer was written in %d packets", len(packets))
+	}
+
+	d.writeColor(make([]byte, 6))
+	if fake.Count(endpoint(cmdWriteColor)) != 1 {
+		t.Errorf("minimal buffer was not written")
+	}
+}
+
+func TestKeepAliveStopsOnStop(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+	d.KeepAliveInterval = 5
+	d.setKeepAlive()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for fake.Count(endpoint(cmdKeepAlive)) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if fake.Count(endpoint(cmdKeepAlive)) < 2 {
+		t.Fatalf("keepalive was not sent")
+	}
+
+	d.Stop()
+	time.Sleep(20 * time.Millisecond) // Keepalive in progress during Stop can still finish
+	sent := fake.Count(endpoint(cmdKeepAlive))
+	time.Sleep(50 * time.Millisecond)
+	if after := fake.Count(endpoint(cmdKeepAlive)); after != sent {
+		t.Errorf("keepalive kept running after Stop, %d packets sent", after-sent)
+	}
+}
+
+func TestStopWithoutGoroutines(t *testing.T) {
+	fake := hidtest.New("K65 Plus")
+	d := newForTest(fake)
+
+	// Stop must not block on refresh or keepalive channels when those goroutines were never started
+	done := make(chan struct{})
+	go func() {
+		d.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Stop blocked without running goroutines")
+	}
+
+	if !fake.Closed() {
+		t.Errorf("HID device was not closed")
+	}
+}
+
+func TestUpdateDeviceColorConcurrent(t *testing.T) {
+	d, _ := newTestDevice(t)
+	defer d.stopRgb()
+	defer d.cancelColorFlush()
+
+	var keyIds []int
+	for _, row := range d.DeviceProfile.Keyboards["default"].Row {
+		for keyId := range row.Keys {
+			keyIds = append(keyIds, keyId)
+		}
+	}
+
+	// Every writer owns a set of keys, so the last color of each key is known
+	writers, rounds := 8, 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < rounds; n++ {
+				for k := i; k < len(keyIds); k += writers {
+					color := rgb.Color{Red: float64(i * 30), Green: float64(n), Blue: 255, Brightness: 1}
+					d.UpdateDeviceColor(keyIds[k], 0, color)
+				}
+			}
+		}(i)
+	}
+
+	// RGB is rendered from keyboard colors while they are changed
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 50; n++ {
+			d.flushColor()
+		}
+	}()
+	wg.Wait()
+
+	owner := make(map[int]int, len(keyIds))
+	for k, keyId := range keyIds {
+		owner[keyId] = k % writers
+	}
+	for _, row := range d.DeviceProfile.Keyboards["default"].Row {
+		for keyId, key := range row.Keys {
+			red, green := float64(owner[keyId]*30), float64(rounds-1)
+			if key.Color.Red != red || key.Color.Green != green || key.Color.Blue != 255 {
+				t.Errorf("key %d color is %v, expected %.0f %.0f 255", keyId, key.Color, red, green)
+			}
+		}
+	}
+}
+
+// readTestProfile will read device profile file saved by a test
+func readTestProfile(t *testing.T, path string) *DeviceProfile {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unable to open profile %s: %v", path, err)
+	}
+	defer file.Close()
+	pf := &DeviceProfile{}
+	if err = json.NewDecoder(file).Decode(pf); err != nil {
+		t.Fatalf("unable to decode profile %s: %v", path, err)
+	}
+	return pf
+}
+
+func TestChangeDeviceProfileActive(t *testing.T) {
+	d, _ := newTestDevice(t)
+	defer d.stopRgb()
+
+	profileDirectory := pwd + "/database/profiles/"
+	d.DeviceProfile.Path = profileDirectory + d.Serial + ".json"
+	if err := d.writeProfileFile(d.DeviceProfile); err != nil {
+		t.Fatalf("unable to write profile: %v", err)
+	}
+	for _, name := range []string{"first", "second"} {
+		pf := *d.DeviceProfile
+		pf.Active = false
+		pf.Path = profileDirectory + d.Serial + "-" + name + ".json"
+		pf.Keyboards = map[string]*keyboards.Keyboard{"default": d.DeviceProfile.Keyboards["default"].Clone()}
+		if err := d.writeProfileFile(&pf); err != nil {
+			t.Fatalf("unable to write profile: %v", err)
+		}
+		d.UserProfiles[name] = &pf
+	}
+
+	untouched, err := os.ReadFile(d.UserProfiles["second"].Path)
+	if err != nil {
+		t.Fatalf("unable to read profile: %v", err)
+	}
+
+	if result := d.ChangeDeviceProfile("first"); result != common.Success {
+		t.Fatalf("unable to change profile, result %v", result)
+	}
+
+	expected := map[string]bool{"default": false, "first": true, "second": false}
+	for name, active := range expected {
+		if d.UserProfiles[name].Active != active {
+			t.Errorf("profile %s active is %v, expected %v", name, d.UserProfiles[name].Active, active)
+		}
+		if pf := readTestProfile(t, d.UserProfiles[name].Path); pf.Active != active {
+			t.Errorf("profile file %s active is %v, expected %v", name, pf.Active, active)
+		}
+	}
+	if d.DeviceProfile != d.UserProfiles["first"] {
+		t.Errorf("active device profile is not the selected profile")
+	}
+
+	current, err := os.ReadFile(d.UserProfiles["second"].Path)
+	if err != nil {
+		t.Fatalf("unable to read profile: %v", err)
+	}
+	if !bytes.Equal(untouched, current) {
+		t.Errorf("profile that wasn't part of the change was written")
+	}
+}
+
+func TestSaveUserProfileKeepsActiveProfile(t *testing.T) {
+	d, _ := newTestDevice(t)
+	defer d.stopRgb()
+
+	activePath := pwd + "/database/profiles/" + d.Serial + ".json"
+	d.DeviceProfile.Path = activePath
+	if result := d.SaveUserProfile("saved"); result != common.Success {
+		t.Fatalf("unable to save profile, result %v", result)
+	}
+	if d.DeviceProfile.Path != activePath || !d.DeviceProfile.Active {
+		t.Errorf("active profile was changed to path %s, active %v", d.DeviceProfile.Path, d.DeviceProfile.Active)
+	}
+
+	saved := readTestProfile(t, pwd+"/database/profiles/"+d.Serial+"-saved.json")
+	if saved.Active {
+		t.Errorf("saved profile is active")
+	}
+}
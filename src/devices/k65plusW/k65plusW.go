@@ -14,49 +14,193 @@ import (
 	"OpenLinkHub/src/logger"
 	"OpenLinkHub/src/rgb"
 	"OpenLinkHub/src/temperatures"
+	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/sstallion/go-hid"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
-// DeviceProfile struct contains all device profile
+// DeviceProfile struct contains all device profile. Profiles saved before JSON tags were added used Go field
+// names as keys. They are still decoded, since encoding/json matches keys case-insensitively
 type DeviceProfile struct {
-	Active          bool
-	Path            string
-	Product         string
-	Serial          string
-	LCDMode         uint8
-	LCDRotation     uint8
-	Brightness      uint8
-	RGBProfile      string
-	Label           string
-	Layout          string
-	Keyboards       map[string]*keyboards.Keyboard
-	Profile         string
-	Profiles        []string
-	ControlDial     int
-	BrightnessLevel uint16
-	SleepMode       int
+	Version          int                            `json:"version"`
+	Active           bool                           `json:"active"`
+	Path             string                         `json:"path"`
+	Product          string                         `json:"product"`
+	Serial           string                         `json:"serial"`
+	LCDMode          uint8                          `json:"lcdMode"`     // Reserved, keyboard has no LCD. Value is only kept in profile file
+	LCDRotation      uint8                          `json:"lcdRotation"` // Reserved, keyboard has no LCD. Value is only kept in profile file
+	Brightness       uint8                          `json:"brightness"`
+	RGBProfile       string                         `json:"rgbProfile"`
+	Label            string                         `json:"label"`
+	Description      string                         `json:"description,omitempty"` // Friendly description of user profile
+	Tags             []string                       `json:"tags,omitempty"`
+	Layout           string                         `json:"layout"`
+	PhysicalLayout   string                         `json:"physicalLayout"`
+	Keyboards        map[string]*keyboards.Keyboard `json:"keyboards"`
+	Profile          string                         `json:"profile"`
+	Profiles         []string                       `json:"profiles"`
+	ControlDial      int                            `json:"controlDial"`
+	BrightnessLevel  uint16                         `json:"brightnessLevel"`
+	SleepMode        int                            `json:"sleepMode"`
+	DialVolumeStep   int                            `json:"dialVolumeStep"`
+	DialAcceleration int                            `json:"dialAcceleration"`
+	DialInvert       bool                           `json:"dialInvert"`
+	DialLongPress    int                            `json:"dialLongPress"`
+	DialBindings     ControlDialBindings            `json:"dialBindings"` // Action of every control dial gesture. ControlDial and DialLongPress are kept in sync
+	TempMin          float64                        `json:"tempMin"`
+	TempMax          float64                        `json:"tempMax"`
+	ColorOrder       string                         `json:"colorOrder"`
+	AppBindings      map[string]string              `json:"appBindings"`    // Executable name to keyboard profile
+	AppFallback      string                         `json:"appFallback"`    // Keyboard profile used when no bound application is running
+	IdleTimeout      int                            `json:"idleTimeout"`    // Seconds without input before brightness is dimmed, 0 disables dimming
+	IdleBrightness   uint16                         `json:"idleBrightness"` // Brightness level of idle device
+	MirrorTarget     string                         `json:"mirrorTarget"`   // Serial of a device mirroring RGB profile and color changes
+	NightMode        bool                           `json:"nightMode"`
+	NightStart       string                         `json:"nightStart"` // Start of night mode, 15:04 format
+	NightEnd         string                         `json:"nightEnd"`   // End of night mode, can be on the next day
+	BootProfile      string                         `json:"bootProfile"`
+	// HidPath is HID path of a device when profile was saved. Identical devices can report the same serial,
+	// in that case serial of every next device is extended with its HID interface and HidPath is used
+	// to find profiles this device saved under its reported serial. See common.ClaimDeviceSerial
+	HidPath string `json:"hidPath"`
+}
+
+// ControlDialBindings struct contains action of every control dial gesture
+type ControlDialBindings struct {
+	RotateAction    int `json:"rotateAction"`
+	ClickAction     int `json:"clickAction"`
+	LongPressAction int `json:"longPressAction"`
+}
+
+// DeviceStatus struct contains current device status
+type DeviceStatus struct {
+	Serial          string   `json:"serial"`
+	Product         string   `json:"product"`
+	Firmware        string   `json:"firmware"`
+	DongleFirmware  string   `json:"dongleFirmware"`
+	Profile         string   `json:"profile"`
+	RGBProfile      string   `json:"rgbProfile"`
+	Brightness      uint16   `json:"brightness"`
+	CorruptProfiles []string `json:"corruptProfiles"`
+	Muted           bool     `json:"muted"`
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags"`
+}
+
+// ManagedDevice struct contains metadata of a device component or HID interface
+type ManagedDevice struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"` // keyboard, dongle or interface
+	Firmware     string `json:"firmware,omitempty"`
+	InterfaceNbr int    `json:"interfaceNbr"` // -1 for device components
+	Role         string `json:"role,omitempty"`
+	Connected    bool   `json:"connected"`
+}
+
+// TransferError struct contains a failed device transfer
+type TransferError struct {
+	Time     time.Time `json:"time"`
+	Endpoint string    `json:"endpoint"`
+	Error    string    `json:"error"`
+}
+
+// Diagnostics struct contains device state attached to bug reports. Every section is marked by its data source
+type Diagnostics struct {
+	Device  DeviceDiagnostics    `json:"device"`  // Read from a device and its HID interfaces
+	Profile ProfileDiagnostics   `json:"profile"` // Stored in device profile
+	Config  config.Configuration `json:"config"`  // Daemon configuration
+}
+
+// DeviceDiagnostics struct contains data read from a device
+type DeviceDiagnostics struct {
+	Serial         string          `json:"serial"`
+	Product        string          `json:"product"`
+	VendorId       uint16          `json:"vendorId"`
+	ProductId      uint16          `json:"productId"`
+	Firmware       string          `json:"firmware"`
+	DongleFirmware string          `json:"dongleFirmware"`
+	HidPath        string          `json:"hidPath"`
+	Interfaces     []ManagedDevice `json:"interfaces"`
+	Unplugged      bool            `json:"unplugged"`
+	TransferErrors []TransferError `json:"transferErrors"`
+}
+
+// ProfileDiagnostics struct contains active device profile settings
+type ProfileDiagnostics struct {
+	Profile        string `json:"profile"`
+	RGBProfile     string `json:"rgbProfile"`
+	Brightness     uint16 `json:"brightness"`
+	Layout         string `json:"layout"`
+	PhysicalLayout string `json:"physicalLayout"`
+	Version        int    `json:"version"`
+}
+
+// hidDevice is a subset of *hid.Device used for device communication, allowing a fake device to be injected
+type hidDevice interface {
+	Write(b []byte) (int, error)
+	Read(b []byte) (int, error)
+	GetMfrStr() (string, error)
+	GetProductStr() (string, error)
+	GetSerialNbr() (string, error)
+	GetDeviceInfo() (*hid.DeviceInfo, error)
+	Close() error
 }
 
 type Device struct {
 	Debug              bool
-	dev                *hid.Device
+	Simulate           bool
+	dev                hidDevice
+	hidPath            string
+	reportedSerial     string // Serial reported by firmware, set only when it was extended with HID path
 	listener           *hid.Device
+	listenerPath       string
+	listenerExit       chan bool
+	listenerDone       chan bool
+	mutexListener      sync.Mutex
+	brightnessLevel    uint16
+	mutexBrightness    sync.Mutex
+	profileHandlers    []func(serial, profile string)
+	unplugHandlers     []func(serial string)
+	mutexHandlers      sync.Mutex
+	mutexErrors        sync.Mutex
+	transferErrors     []TransferError
+	mutexColor         sync.Mutex
+	colorFlush         *time.Timer
+	profileSave        *time.Timer
+	mutexSave          sync.Mutex
+	mutexIdentify      sync.Mutex
+	mutexMute          sync.Mutex
+	muted              bool
+	idleTimer          *time.Timer
+	idleDimmed         bool
+	idleGeneration     int
+	mutexIdle          sync.Mutex
+	nightExit          chan bool
+	nightActive        bool
+	mutexNight         sync.Mutex
 	Manufacturer       string `json:"manufacturer"`
 	Product            string `json:"product"`
 	Serial             string `json:"serial"`
 	Firmware           string `json:"firmware"`
 	DongleFirmware     string `json:"dongleFirmware"`
-	activeRgb          *rgb.ActiveRGB
+	rgbCancel          context.CancelFunc
+	rgbDone            chan struct{}
+	mutexRgb           sync.Mutex
 	UserProfiles       map[string]*DeviceProfile `json:"userProfiles"`
+	CorruptProfiles    []string                  `json:"corruptProfiles"`
 	Devices            map[int]string            `json:"devices"`
 	DeviceProfile      *DeviceProfile
 	OriginalProfile    *DeviceProfile
@@ -69,9 +213,27 @@ type Device struct {
 	Layouts            []string
 	ProductId          uint16
 	ControlDialOptions map[int]string
+	ClickOptions       map[int]string
+	LongPressOptions   map[int]string
 	RGBModes           map[string]string
 	SleepModes         map[int]string
 	Rgb                *rgb.RGB
+	KeepAliveInterval  int
+	keepAliveFailures  int
+	asleep             bool
+	mutexSleep         sync.Mutex
+	writeFailures      int
+	unplugged          bool
+	previewActive      bool
+	previewRgbProfile  string
+	startupEffect      *time.Timer
+	timerKeepAlive     *time.Ticker
+	keepAliveChan      chan bool
+	timerRefresh       *time.Ticker
+	autoRefreshChan    chan bool
+	appWatcherExit     chan bool
+	appBound           bool
+	appPrevious        string
 }
 
 var (
@@ -81,42 +243,151 @@ var (
 	cmdActivateLed          = []byte{0x0d, 0x01, 0x60, 0x6d}
 	cmdBrightness           = []byte{0x01, 0x02, 0x00}
 	cmdGetFirmware          = []byte{0x02, 0x13}
-	dataTypeSetColor        = []byte{0x7e, 0x20, 0x01}
+	dataTypeStaticColor     = []byte{0x7e, 0x20, 0x01}
+	dataTypePerKeyColor     = []byte{0x12, 0x00}
+	perKeyColor             = false // Per-key color packet is not yet confirmed on hardware
 	dataTypeSubColor        = []byte{0x07, 0x01}
 	cmdWriteColor           = []byte{0x06, 0x01}
 	cmdSleep                = []byte{0x01, 0x0e, 0x00}
 	cmdDongle               = 0x08
 	cmdKeyboard             = 0x09
-	deviceRefreshInterval   = 1000
 	deviceKeepAlive         = 20000
-	timer                   = &time.Ticker{}
-	timerKeepAlive          = &time.Ticker{}
-	authRefreshChan         = make(chan bool)
-	keepAliveChan           = make(chan bool)
+	maxKeepAliveFailures    = 3
+	maxWriteFailures        = 5
+	errDeviceUnplugged      = errors.New("device is unplugged")
+	errRawTransferDisabled  = errors.New("raw transfer is available only in debug or simulate mode")
+	errRawTransferLength    = errors.New("raw transfer packet is empty or too long")
+	firmwareReadAttempts    = 2
+	firmwareUnknown         = "unknown"
 	mutex                   sync.Mutex
-	transferTimeout         = 500
+	listenerRetryInterval   = 1000
+	listenerMaxRetries      = 6
+	listenerReadTimeout     = 100
+	listenerProbeTimeout    = 50
+	identifyFlashes         = 4
+	identifyInterval        = 250
+	profileSwitchDebounce   = 250
+	defaultVolumeStep       = 5
+	minVolumeStep           = 1
+	maxVolumeStep           = 25
+	brightnessStep          = 100
+	dialAccelerationWindow  = 150
+	dialLongPressDuration   = 600
+	colorFlushDelay         = 50
+	profileSaveDelay        = 500
+	appWatchInterval        = 2000
+	maxIdleTimeout          = 3600
+	idleFadeSteps           = 10
+	idleFadeInterval        = 100
+	nightModeInterval       = 30000
+	nightModeBrightness     = 150
+	nightModeLayout         = "15:04"
+	nightModeColor          = rgb.Color{Red: 255, Green: 120, Blue: 30, Brightness: 1} // Warm white
+	maxStartupDuration      = 10000
+	maxDescriptionLength    = 128
+	maxTransferErrors       = 20
+	maxTagLength            = 32
+	maxTags                 = 16
+	defaultDialAcceleration = 3
+	minDialAcceleration     = 1
+	maxDialAcceleration     = 10
+	// Click action of control dial modes, used when mode is selected instead of a single gesture action
+	dialClickActions        = map[int]int{dialRotateVolume: dialLongPressMute, dialRotateBrightness: dialLongPressBrightness}
+	profileVersion          = 2 // Increase on every DeviceProfile change that requires migration
 	bufferSize              = 64
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
 	headerWriteSize         = 4
 	maxBufferSizePerRequest = 61
+	colorPacketLength       = 371
+	breathingStep           = 0.02
 	keyboardKey             = "k65plusW-default"
-	defaultLayout           = "k65plusW-default-US"
+)
+
+// Control dial rotate actions
+const (
+	dialRotateDisabled   = 0
+	dialRotateVolume     = 1
+	dialRotateBrightness = 2
+	dialRotateProfile    = 3
+)
+
+// Control dial long press actions
+const (
+	dialLongPressDisabled   = 0 // Dial press runs click action immediately
+	dialLongPressMute       = 1
+	dialLongPressProfile    = 2
+	dialLongPressBrightness = 3
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
 	// Set global working directory
 	pwd = config.GetConfig().ConfigPath
 
-	dev, err := hid.OpenPath(key)
-	if err != nil {
-		logger.Log(logger.Fields{"error": err, "vendorId": vendorId, "productId": productId}).Error("Unable to open HID device")
-		return nil
+	// Simulated device has no HID handle, all packets are only logged
+	var dev hidDevice
+	var err error
+	simulate := config.GetConfig().Simulate
+	if !simulate {
+		handle, e := hid.OpenPath(key)
+		if e != nil {
+			logger.Log(logger.Fields{"error": e, "vendorId": vendorId, "productId": productId}).Error("Unable to open HID device")
+			return nil
+		}
+		dev = handle
 	}
 
 	// Init new struct with HID device
-	d := &Device{
+	d := newDevice(dev, vendorId, productId, key, simulate)
+	d.getDebugMode() // Debug mode
+	if err = d.getManufacturer(); err != nil {
+		d.initFailed(err, "Unable to get manufacturer")
+		return nil
+	}
+	if err = d.getSerial(); err != nil {
+		d.initFailed(err, "Unable to get device serial number")
+		return nil
+	}
+	d.loadRgb() // Load RGB
+	if err = d.setSoftwareMode(); err != nil {
+		d.initFailed(err, "Unable to change device mode")
+		return nil
+	}
+	if err = d.initLeds(); err != nil {
+		d.initFailed(err, "Unable to initialize LED ports")
+		return nil
+	}
+	if err = d.getDeviceFirmware(); err != nil {
+		d.initFailed(err, "Unable to get device firmware")
+		return nil
+	}
+	if err = d.getDongleFirmware(); err != nil {
+		d.initFailed(err, "Unable to get dongle firmware")
+		return nil
+	}
+	d.discoverDevices()     // Components and HID interfaces
+	d.loadDeviceProfiles()  // Load all device profiles
+	d.saveDeviceProfile()   // Save profile
+	d.setBootProfile()      // Boot profile
+	d.setAutoRefresh()      // Set auto device refresh
+	d.setKeepAlive()        // Keepalive
+	d.setDeviceColor()      // Device color
+	d.playStartupEffect()   // Startup effect
+	d.setBrightnessLevel()  // Brightness
+	d.controlDialListener() // Control Dial
+	d.setSleepTimer()       // Sleep
+	d.setAppWatcher()       // Application profile bindings
+	d.resetIdleTimer()      // Idle dimming
+	d.setNightMode()        // Night mode schedule
+	return d
+}
+
+// newDevice will create a device struct with default values for a given HID handle
+func newDevice(dev hidDevice, vendorId, productId uint16, key string, simulate bool) *Device {
+	return &Device{
 		dev:       dev,
+		hidPath:   key,
+		Simulate:  simulate,
 		Template:  "k65plusW.html",
 		VendorId:  vendorId,
 		ProductId: productId,
@@ -126,25 +397,42 @@ func Init(vendorId, productId uint16, key string) *Device {
 			2: "66 %",
 			3: "100 %",
 		},
-		Product:     "K65 Plus Wireless",
-		LEDChannels: 123,
-		Layouts:     keyboards.GetLayouts(keyboardKey),
+		Product:           "K65 Plus Wireless",
+		LEDChannels:       123,
+		KeepAliveInterval: deviceKeepAlive,
+		Layouts:           keyboards.GetLayouts(keyboardKey),
 		ControlDialOptions: map[int]string{
-			1: "Volume Control",
-			2: "Brightness",
+			dialRotateDisabled:   "Disabled",
+			dialRotateVolume:     "Volume Control",
+			dialRotateBrightness: "Brightness",
+			dialRotateProfile:    "Profile Switch",
+		},
+		ClickOptions: map[int]string{
+			dialLongPressDisabled:   "Disabled",
+			dialLongPressMute:       "Mute",
+			dialLongPressProfile:    "Next Profile",
+			dialLongPressBrightness: "Brightness Toggle",
+		},
+		LongPressOptions: map[int]string{
+			dialLongPressDisabled:   "Disabled",
+			dialLongPressMute:       "Mute",
+			dialLongPressProfile:    "Next Profile",
+			dialLongPressBrightness: "Brightness Toggle",
 		},
 		RGBModes: map[string]string{
-			"watercolor":    "Watercolor",
-			"colorpulse":    "Color Pulse",
-			"colorshift":    "Color Shift",
-			"colorwave":     "Color Wave",
-			"rain":          "Rain",
-			"rainbowwave":   "Rainbow Wave",
-			"spiralrainbow": "Spiral Rainbow",
-			"tlk":           "Type Lighting - Key",
-			"tlr":           "Type Lighting - Ripple",
-			"keyboard":      "Keyboard",
-			"off":           "Off",
+			"watercolor":      "Watercolor",
+			"colorpulse":      "Color Pulse",
+			"colorshift":      "Color Shift",
+			"colorwave":       "Color Wave",
+			"rain":            "Rain",
+			"rainbowwave":     "Rainbow Wave",
+			"spiralrainbow":   "Spiral Rainbow",
+			"tlk":             "Type Lighting - Key",
+			"tlr":             "Type Lighting - Ripple",
+			"keyboard":        "Keyboard",
+			"breathing":       "Breathing",
+			"cpu-temperature": "CPU Temperature",
+			"off":             "Off",
 		},
 		SleepModes: map[int]string{
 			5:  "5 minutes",
@@ -154,50 +442,58 @@ func Init(vendorId, productId uint16, key string) *Device {
 			60: "1 hour",
 		},
 	}
+}
 
-	d.getDebugMode()        // Debug mode
-	d.getManufacturer()     // Manufacturer
-	d.getSerial()           // Serial
-	d.loadRgb()             // Load RGB
-	d.setSoftwareMode()     // Activate software mode
-	d.initLeds()            // Init LED ports
-	d.getDeviceFirmware()   // Firmware
-	d.getDongleFirmware()   // Dongle firmware
-	d.loadDeviceProfiles()  // Load all device profiles
-	d.saveDeviceProfile()   // Save profile
-	d.setAutoRefresh()      // Set auto device refresh
-	d.setKeepAlive()        // Keepalive
-	d.setDeviceColor()      // Device color
-	d.controlDialListener() // Control Dial
-	d.setBrightnessLevel()  // Brightness
-	d.setSleepTimer()       // Sleep
-	return d
+// initFailed will log device initialization error and release HID device
+func (d *Device) initFailed(err error, message string) {
+	logger.Log(logger.Fields{"error": err, "vendorId": d.VendorId, "productId": d.ProductId, "serial": d.Serial}).Error(message)
+	if len(d.Serial) > 0 {
+		common.ReleaseDeviceSerial(d.Serial)
+	}
+	if d.dev != nil {
+		if e := d.dev.Close(); e != nil {
+			logger.Log(logger.Fields{"error": e}).Error("Unable to close HID device")
+		}
+	}
 }
 
 // Stop will stop all device operations and switch a device back to hardware mode
 func (d *Device) Stop() {
 	logger.Log(logger.Fields{"serial": d.Serial}).Info("Stopping device...")
-	if d.activeRgb != nil {
-		d.activeRgb.Stop()
-	}
-	timer.Stop()
-	authRefreshChan <- true
-
-	timerKeepAlive.Stop()
-	keepAliveChan <- true
+	defer common.ReleaseDeviceSerial(d.Serial)
+	d.cancelStartupEffect()
+	d.stopRgb()
+	d.flushDeviceProfile()
+	d.cancelColorFlush()
+	d.stopAutoRefresh()
+	d.stopKeepAlive()
+	d.stopControlDialListener()
+	d.stopAppWatcher()
+	d.cancelIdleTimer()
+	d.stopNightMode()
+
+	// Device keeps last software colors when configured, otherwise firmware lighting takes over
+	if config.GetConfig().KeepSoftwareModeOnExit {
+		logger.Log(logger.Fields{"serial": d.Serial}).Info("Keeping software mode on exit")
+	} else {
+		if d.DeviceProfile != nil {
+			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
+				var buf = make([]byte, 93)
+				buf[2] = 0x01
+				buf[3] = 0xff
+				buf[4] = 0xff
+				buf[5] = 0xff
+				buf[6] = 0xff
+				d.writeColor([]byte{0x22, 0x00, 0x03, 0x04}, buf)
+			}
+		}
 
-	if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
-		var buf = make([]byte, 93)
-		buf[2] = 0x01
-		buf[3] = 0xff
-		buf[4] = 0xff
-		buf[5] = 0xff
-		buf[6] = 0xff
-		dataTypeSetColor = []byte{0x22, 0x00, 0x03, 0x04}
-		d.writeColor(buf)
+		err := d.setHardwareMode()
+		if err != nil {
+			logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to change device mode")
+		}
 	}
 
-	d.setHardwareMode()
 	if d.dev != nil {
 		err := d.dev.Close()
 		if err != nil {
@@ -264,6 +560,103 @@ func (d *Device) loadRgb() {
 	}
 }
 
+// GetDeviceStatus will return current device status
+func (d *Device) GetDeviceStatus() DeviceStatus {
+	status := DeviceStatus{
+		Serial:          d.Serial,
+		Product:         d.Product,
+		Firmware:        d.Firmware,
+		DongleFirmware:  d.DongleFirmware,
+		Brightness:      d.getBrightnessLevel(),
+		CorruptProfiles: d.CorruptProfiles,
+		Muted:           d.isMuted(),
+	}
+
+	if d.DeviceProfile != nil {
+		status.Profile = d.DeviceProfile.Profile
+		status.RGBProfile = d.DeviceProfile.RGBProfile
+		status.Description = d.DeviceProfile.Description
+		status.Tags = d.DeviceProfile.Tags
+	}
+	return status
+}
+
+// ListDevices will return components and HID interfaces of a device
+func (d *Device) ListDevices() []ManagedDevice {
+	return d.discoverDevices()
+}
+
+// discoverDevices will return dongle, keyboard and HID interfaces of a device. Devices map is refreshed with their names
+func (d *Device) discoverDevices() []ManagedDevice {
+	list := []ManagedDevice{
+		{
+			Id:           0,
+			Name:         "Wireless Dongle",
+			Type:         "dongle",
+			Firmware:     d.DongleFirmware,
+			InterfaceNbr: -1,
+			Connected:    !d.unplugged,
+		},
+		{
+			Id:           1,
+			Name:         d.Product,
+			Type:         "keyboard",
+			Firmware:     d.Firmware,
+			InterfaceNbr: -1,
+			Connected:    !d.unplugged && !d.isAsleep(), // Sleeping keyboard doesn't respond until it wakes up
+		},
+	}
+	list = append(list, d.getInterfaces(len(list))...)
+
+	names := make(map[int]string, len(list))
+	for _, device := range list {
+		names[device.Id] = device.Name
+	}
+	d.Devices = names
+	return list
+}
+
+// getInterfaces will enumerate HID interfaces of a device and mark ones used for control and control dial
+func (d *Device) getInterfaces(firstId int) []ManagedDevice {
+	var interfaces []ManagedDevice
+	if d.Simulate {
+		return interfaces
+	}
+
+	enum := hid.EnumFunc(func(info *hid.DeviceInfo) error {
+		role := ""
+		switch info.Path {
+		case d.hidPath:
+			role = "control"
+		case d.listenerPath:
+			role = "dial"
+		}
+		interfaces = append(interfaces, ManagedDevice{
+			Id:           firstId + len(interfaces),
+			Name:         fmt.Sprintf("Interface %d", info.InterfaceNbr),
+			Type:         "interface",
+			InterfaceNbr: info.InterfaceNbr,
+			Role:         role,
+			Connected:    true,
+		})
+		return nil
+	})
+
+	if err := hid.Enumerate(d.VendorId, d.ProductId, enum); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to enumerate HID interfaces")
+	}
+	return interfaces
+}
+
+// GetLayoutGeometry will return geometry of currently selected keyboard layout
+func (d *Device) GetLayoutGeometry() *keyboards.Geometry {
+	layout := "US"
+	if d.DeviceProfile != nil && len(d.DeviceProfile.Layout) > 0 {
+		layout = d.DeviceProfile.Layout
+	}
+	return keyboards.GetGeometry(fmt.Sprintf("%s-%s", keyboardKey, layout), d.LEDChannels)
+}
+
 // GetRgbProfile will return rgb.Profile struct
 func (d *Device) GetRgbProfile(profile string) *rgb.Profile {
 	if d.Rgb == nil {
@@ -276,6 +669,15 @@ func (d *Device) GetRgbProfile(profile string) *rgb.Profile {
 	return nil
 }
 
+// GetRgbModes will return all RGB modes supported by the device
+func (d *Device) GetRgbModes() map[string]string {
+	modes := make(map[string]string, len(d.RGBModes))
+	for mode, name := range d.RGBModes {
+		modes[mode] = name
+	}
+	return modes
+}
+
 // GetDeviceTemplate will return device template name
 func (d *Device) GetDeviceTemplate() string {
 	return d.Template
@@ -287,12 +689,18 @@ func (d *Device) getDebugMode() {
 }
 
 // getManufacturer will return device manufacturer
-func (d *Device) getManufacturer() {
+func (d *Device) getManufacturer() error {
+	if d.Simulate {
+		d.Manufacturer = "Corsair"
+		return nil
+	}
+
 	manufacturer, err := d.dev.GetMfrStr()
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to get manufacturer")
+		return err
 	}
 	d.Manufacturer = manufacturer
+	return nil
 }
 
 // getProduct will return device name
@@ -305,167 +713,298 @@ func (d *Device) getProduct() {
 }
 
 // getSerial will return device serial number
-func (d *Device) getSerial() {
+func (d *Device) getSerial() error {
+	if d.Simulate {
+		d.Serial = fmt.Sprintf("SIMULATED%04X", d.ProductId)
+		return nil
+	}
+
 	serial, err := d.dev.GetSerialNbr()
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to get device serial number")
+		return err
+	}
+
+	// Interface number and usage page identify a device with the same serial across restarts
+	interfaceNbr, usagePage := -1, uint16(0)
+	if info, e := d.dev.GetDeviceInfo(); e == nil {
+		interfaceNbr, usagePage = info.InterfaceNbr, info.UsagePage
+	}
+
+	unique, changed := common.ClaimDeviceSerial(serial, d.hidPath, interfaceNbr, usagePage)
+	if changed {
+		logger.Log(logger.Fields{"serial": serial, "uniqueSerial": unique, "path": d.hidPath}).Warn("Device with the same serial is already connected. Using HID interface to identify device")
+		d.reportedSerial = serial
 	}
-	d.Serial = serial
+	d.Serial = unique
+	return nil
 }
 
 // setHardwareMode will switch a device to hardware mode
-func (d *Device) setHardwareMode() {
+func (d *Device) setHardwareMode() error {
 	_, err := d.transfer(cmdHardwareMode, nil, byte(cmdKeyboard))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
+		return err
 	}
 
 	_, err = d.transfer(cmdHardwareMode, nil, byte(cmdDongle))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
+		return err
 	}
+	return nil
 }
 
 // setSoftwareMode will switch a device to software mode
-func (d *Device) setSoftwareMode() {
+func (d *Device) setSoftwareMode() error {
 	_, err := d.transfer(cmdSoftwareMode, nil, byte(cmdDongle))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
+		return err
 	}
 
 	_, err = d.transfer(cmdSoftwareMode, nil, byte(cmdKeyboard))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
+		return err
 	}
+	return nil
 }
 
-// getDongleFirmware will return a dongle firmware version out as string
-func (d *Device) getDongleFirmware() {
-	fw, err := d.transfer(
-		cmdGetFirmware,
-		nil,
-		byte(cmdDongle),
-	)
-	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to write to a device")
+// readFirmware will read firmware version for a given command, retrying once if response is invalid
+func (d *Device) readFirmware(command byte) (string, error) {
+	for i := 0; i < firmwareReadAttempts; i++ {
+		fw, err := d.transfer(cmdGetFirmware, nil, command)
+		if err != nil {
+			return "", err
+		}
+		if firmware, ok := parseFirmware(fw); ok {
+			return firmware, nil
+		}
 	}
+	logger.Log(logger.Fields{"serial": d.Serial, "command": command}).Warn("Invalid firmware response, firmware version is unknown")
+	return firmwareUnknown, nil
+}
 
+// parseFirmware will convert firmware response into a version string. Returns false if response is too short or empty
+func parseFirmware(fw []byte) (string, bool) {
+	if len(fw) < 7 {
+		return "", false
+	}
 	v1, v2, v3 := int(fw[3]), int(fw[4]), int(binary.LittleEndian.Uint16(fw[5:7]))
-	d.DongleFirmware = fmt.Sprintf("%d.%d.%d", v1, v2, v3)
+	if v1 == 0 && v2 == 0 && v3 == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%d.%d.%d", v1, v2, v3), true
 }
 
-// getDeviceFirmware will return a device firmware version out as string
-func (d *Device) getDeviceFirmware() {
-	fw, err := d.transfer(
-		cmdGetFirmware,
-		nil,
-		byte(cmdKeyboard),
-	)
+// getDongleFirmware will return a dongle firmware version out as string
+func (d *Device) getDongleFirmware() error {
+	firmware, err := d.readFirmware(byte(cmdDongle))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to write to a device")
+		return err
 	}
+	d.DongleFirmware = firmware
+	return nil
+}
 
-	v1, v2, v3 := int(fw[3]), int(fw[4]), int(binary.LittleEndian.Uint16(fw[5:7]))
-	d.Firmware = fmt.Sprintf("%d.%d.%d", v1, v2, v3)
+// getDeviceFirmware will return a device firmware version out as string
+func (d *Device) getDeviceFirmware() error {
+	firmware, err := d.readFirmware(byte(cmdKeyboard))
+	if err != nil {
+		return err
+	}
+	d.Firmware = firmware
+	return nil
 }
 
 // initLeds will initialize LED ports
-func (d *Device) initLeds() {
+func (d *Device) initLeds() error {
 	_, err := d.transfer(cmdActivateLed, nil, byte(cmdKeyboard))
 	if err != nil {
-		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
+		return err
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
+	return nil
 }
 
-// saveDeviceProfile will save device profile for persistent configuration
-func (d *Device) saveDeviceProfile() {
-	profilePath := pwd + "/database/profiles/" + d.Serial + ".json"
+// setDefaultProfileValues will populate device profile with first-run default values
+func (d *Device) setDefaultProfileValues(deviceProfile *DeviceProfile) {
 	keyboardMap := make(map[string]*keyboards.Keyboard, 0)
 
-	deviceProfile := &DeviceProfile{
-		Product: d.Product,
-		Serial:  d.Serial,
-		Path:    profilePath,
+	// RGB, Label
+	deviceProfile.RGBProfile = "keyboard"
+	deviceProfile.Label = "Keyboard"
+	deviceProfile.Active = true
+	layout := keyboards.GetDefaultLayout(keyboardKey)
+	keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout)).Clone()
+	deviceProfile.Keyboards = keyboardMap
+	deviceProfile.Profile = "default"
+	deviceProfile.Profiles = []string{"default"}
+	deviceProfile.Layout = layout
+	deviceProfile.PhysicalLayout = keyboards.GetPhysicalLayout(fmt.Sprintf("%s-%s", keyboardKey, layout))
+	deviceProfile.ControlDial = 1
+	deviceProfile.DialBindings = ControlDialBindings{RotateAction: dialRotateVolume, ClickAction: dialLongPressMute}
+	deviceProfile.BrightnessLevel = 1000
+	deviceProfile.SleepMode = 15
+	deviceProfile.DialVolumeStep = defaultVolumeStep
+	deviceProfile.DialAcceleration = defaultDialAcceleration
+}
+
+// saveDeviceProfile will save device profile for persistent configuration. Saves within profileSaveDelay
+// are coalesced into a single write and reload, so frequent changes like dial ticks don't wear the storage
+func (d *Device) saveDeviceProfile() {
+	if d.DeviceProfile == nil {
+		d.writeDeviceProfile() // First save, device profile has to exist right away
+		return
+	}
+
+	d.mutexSave.Lock()
+	defer d.mutexSave.Unlock()
+	if d.profileSave != nil {
+		return // Save is already pending and will pick up this change
+	}
+	d.profileSave = time.AfterFunc(time.Duration(profileSaveDelay)*time.Millisecond, d.flushDeviceProfile)
+}
+
+// flushDeviceProfile will write pending device profile save right away
+func (d *Device) flushDeviceProfile() {
+	d.mutexSave.Lock()
+	pending := d.profileSave != nil
+	if pending {
+		d.profileSave.Stop()
+		d.profileSave = nil
+	}
+	d.mutexSave.Unlock()
+
+	if pending {
+		d.writeDeviceProfile()
+	}
+}
+
+// cancelDeviceProfileSave will drop pending device profile save
+func (d *Device) cancelDeviceProfileSave() {
+	d.mutexSave.Lock()
+	defer d.mutexSave.Unlock()
+	if d.profileSave != nil {
+		d.profileSave.Stop()
+		d.profileSave = nil
 	}
+}
+
+// writeDeviceProfile will write device profile to a file and update in-memory user profiles
+func (d *Device) writeDeviceProfile() {
+	profilePath := pwd + "/database/profiles/" + d.Serial + ".json"
 
 	// First save, assign saved profile to a device
 	if d.DeviceProfile == nil {
-		// RGB, Label
-		deviceProfile.RGBProfile = "keyboard"
-		deviceProfile.Label = "Keyboard"
-		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
-		deviceProfile.Keyboards = keyboardMap
-		deviceProfile.Profile = "default"
-		deviceProfile.Profiles = []string{"default"}
-		deviceProfile.Layout = "US"
-		deviceProfile.ControlDial = 1
-		deviceProfile.BrightnessLevel = 1000
-		deviceProfile.SleepMode = 15
-	} else {
-		if len(d.DeviceProfile.Layout) == 0 {
-			deviceProfile.Layout = "US"
-		} else {
-			deviceProfile.Layout = d.DeviceProfile.Layout
-		}
-
-		deviceProfile.Active = d.DeviceProfile.Active
-		deviceProfile.Brightness = d.DeviceProfile.Brightness
-		deviceProfile.RGBProfile = d.DeviceProfile.RGBProfile
-		deviceProfile.Label = d.DeviceProfile.Label
-		deviceProfile.Profile = d.DeviceProfile.Profile
-		deviceProfile.Profiles = d.DeviceProfile.Profiles
-		deviceProfile.Keyboards = d.DeviceProfile.Keyboards
-		deviceProfile.ControlDial = d.DeviceProfile.ControlDial
-		deviceProfile.BrightnessLevel = d.DeviceProfile.BrightnessLevel
-		deviceProfile.SleepMode = d.DeviceProfile.SleepMode
-
-		if len(d.DeviceProfile.Path) < 1 {
-			deviceProfile.Path = profilePath
-			d.DeviceProfile.Path = profilePath
-		} else {
-			deviceProfile.Path = d.DeviceProfile.Path
+		deviceProfile := &DeviceProfile{
+			Version: profileVersion,
+			Product: d.Product,
+			Serial:  d.Serial,
+			Path:    profilePath,
+			HidPath: d.hidPath,
+		}
+		d.setDefaultProfileValues(deviceProfile)
+		if err := d.writeProfileFile(deviceProfile); err != nil {
+			return
 		}
-		deviceProfile.LCDMode = d.DeviceProfile.LCDMode
-		deviceProfile.LCDRotation = d.DeviceProfile.LCDRotation
+		d.storeUserProfile(deviceProfile)
+		return
+	}
+
+	// Delayed save runs on its own goroutine. Active profile is normalized in place and written
+	// under the same lock as color changes, so no change is lost to a swapped profile
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	if len(d.DeviceProfile.Path) < 1 {
+		d.DeviceProfile.Path = profilePath
+	}
+	d.DeviceProfile.Version = profileVersion
+	d.DeviceProfile.Product = d.Product
+	d.DeviceProfile.Serial = d.Serial
+	d.DeviceProfile.HidPath = d.hidPath
+	d.normalizeDeviceProfile(d.DeviceProfile)
+
+	deviceProfile := *d.DeviceProfile
+	if d.previewActive {
+		deviceProfile.RGBProfile = d.previewRgbProfile // Previewed RGB mode is never saved
+	}
+	_ = d.writeProfileFile(&deviceProfile)
+}
+
+// normalizeDeviceProfile will replace missing or out of range profile values with defaults.
+// Profiles changed on disk or imported are normalized on load, the same way as on every save
+func (d *Device) normalizeDeviceProfile(pf *DeviceProfile) {
+	if len(pf.Layout) == 0 {
+		pf.Layout = "US"
+	}
+
+	if pf.DialVolumeStep == 0 {
+		pf.DialVolumeStep = defaultVolumeStep
+	}
+
+	if pf.DialAcceleration == 0 {
+		pf.DialAcceleration = defaultDialAcceleration
 	}
+}
 
+// writeProfileFile will write device profile to its file without reloading device profiles
+func (d *Device) writeProfileFile(profile *DeviceProfile) error {
 	// Convert to JSON
-	buffer, err := json.MarshalIndent(deviceProfile, "", "    ")
+	buffer, err := json.MarshalIndent(profile, "", "    ")
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Error("Unable to convert to json format")
-		return
-	}
-
-	// Create profile filename
-	file, fileErr := os.Create(deviceProfile.Path)
-	if fileErr != nil {
-		logger.Log(logger.Fields{"error": err, "location": deviceProfile.Path}).Error("Unable to create new device profile")
-		return
+		return err
 	}
 
 	// Write JSON buffer to file
-	_, err = file.Write(buffer)
+	err = common.WriteFileAtomic(profile.Path, buffer)
 	if err != nil {
-		logger.Log(logger.Fields{"error": err, "location": deviceProfile.Path}).Error("Unable to write data")
-		return
+		logger.Log(logger.Fields{"error": err, "location": profile.Path}).Error("Unable to write device profile")
+		return err
 	}
+	return nil
+}
 
-	// Close file
-	err = file.Close()
-	if err != nil {
-		logger.Log(logger.Fields{"error": err, "location": deviceProfile.Path}).Fatal("Unable to close file handle")
+// storeUserProfile will update in-memory user profiles with saved profile, without reading all profiles from disk.
+// Device color mutex must not be held by a caller
+func (d *Device) storeUserProfile(pf *DeviceProfile) {
+	name := "default"
+	fileName := strings.TrimSuffix(filepath.Base(pf.Path), ".json")
+	if fileName != d.Serial {
+		parts := strings.Split(fileName, "-")
+		if len(parts) < 2 {
+			d.loadDeviceProfiles() // Unknown file name format, profile name is resolved by full reload
+			return
+		}
+		name = parts[1]
+	}
+
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+	if d.UserProfiles == nil {
+		d.UserProfiles = make(map[string]*DeviceProfile)
+	}
+
+	// Known profile is changed in place, so every holder of it sees saved values
+	if profile, ok := d.UserProfiles[name]; ok && profile != nil {
+		*profile = *pf
+		pf = profile
+	} else {
+		d.UserProfiles[name] = pf
 	}
 
-	d.loadDeviceProfiles() // Reload
+	if pf.Active {
+		d.DeviceProfile = pf
+	}
 }
 
 // loadDeviceProfiles will load custom user profiles
 func (d *Device) loadDeviceProfiles() {
+	d.flushDeviceProfile() // Pending changes are written first, so reload doesn't discard them
+
 	profileList := make(map[string]*DeviceProfile, 0)
+	pathProfiles := make(map[string]*DeviceProfile, 0)
 	userProfileDirectory := pwd + "/database/profiles/"
 
 	files, err := os.ReadDir(userProfileDirectory)
@@ -499,7 +1038,7 @@ func (d *Device) loadDeviceProfiles() {
 			fileSerial = fileName
 		}
 
-		if fileSerial != d.Serial {
+		if fileSerial != d.Serial && (len(d.reportedSerial) == 0 || fileSerial != d.reportedSerial) {
 			continue
 		}
 
@@ -510,6 +1049,8 @@ func (d *Device) loadDeviceProfiles() {
 		}
 		if err = json.NewDecoder(file).Decode(pf); err != nil {
 			logger.Log(logger.Fields{"error": err, "serial": d.Serial, "location": profileLocation}).Warn("Unable to decode profile")
+			_ = file.Close()
+			d.quarantineProfile(profileLocation)
 			continue
 		}
 		err = file.Close()
@@ -517,6 +1058,14 @@ func (d *Device) loadDeviceProfiles() {
 			logger.Log(logger.Fields{"location": profileLocation, "serial": d.Serial}).Warn("Failed to close file handle")
 		}
 
+		if pf.Version < profileVersion {
+			logger.Log(logger.Fields{"location": profileLocation, "serial": d.Serial, "version": pf.Version}).Info("Migrating device profile")
+			d.migrateDeviceProfile(pf)
+		}
+		d.normalizeDeviceProfile(pf)
+		d.validateLayout(pf, profileLocation)
+		d.applyPhysicalLayout(pf)
+
 		if pf.Serial == d.Serial {
 			if fileName == d.Serial {
 				profileList["default"] = pf
@@ -525,76 +1074,468 @@ func (d *Device) loadDeviceProfiles() {
 				profileList[name] = pf
 			}
 			logger.Log(logger.Fields{"location": profileLocation, "serial": d.Serial}).Info("Loaded custom user profile")
+		} else if pf.Serial == d.reportedSerial && pf.HidPath == d.hidPath {
+			// Profile was saved by this device before its serial was extended with HID path
+			if fileName == d.reportedSerial {
+				pathProfiles["default"] = pf
+			} else {
+				name := strings.Split(fileName, "-")[1]
+				pathProfiles[name] = pf
+			}
 		}
 	}
-	d.UserProfiles = profileList
-	d.getDeviceProfile()
-}
 
-// getDeviceProfile will load persistent device configuration
-func (d *Device) getDeviceProfile() {
-	if len(d.UserProfiles) == 0 {
-		logger.Log(logger.Fields{"serial": d.Serial}).Warn("No profile found for device. Probably initial start")
-	} else {
-		for _, pf := range d.UserProfiles {
-			if pf.Active {
-				d.DeviceProfile = pf
+	if len(profileList) == 0 && len(pathProfiles) > 0 {
+		// HID path is a secondary key, matched profiles are copied under extended serial
+		for name, pf := range pathProfiles {
+			pf.Serial = d.Serial
+			if name == "default" {
+				pf.Path = userProfileDirectory + d.Serial + ".json"
+			} else {
+				pf.Path = userProfileDirectory + d.Serial + "-" + name + ".json"
+			}
+			if err = d.writeProfileFile(pf); err != nil {
+				continue
 			}
+			profileList[name] = pf
+			logger.Log(logger.Fields{"location": pf.Path, "serial": d.Serial, "path": d.hidPath}).Info("Loaded user profile matched by HID path")
 		}
 	}
+	d.UserProfiles = profileList
+	d.getDeviceProfile()
 }
 
-// keepAlive will keep a device alive
-func (d *Device) keepAlive() {
-	_, err := d.transfer([]byte{0x12}, nil, byte(cmdDongle))
-	if err != nil {
-		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to write to a device")
+// quarantineProfile will move corrupt profile file to a corrupt folder, so it doesn't clash with a later save
+func (d *Device) quarantineProfile(profileLocation string) {
+	name := filepath.Base(profileLocation)
+	if !slices.Contains(d.CorruptProfiles, name) {
+		d.CorruptProfiles = append(d.CorruptProfiles, name)
 	}
 
-	_, err = d.transfer([]byte{0x12}, nil, byte(cmdKeyboard))
-	if err != nil {
-		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to write to a device")
+	corruptDirectory := pwd + "/database/profiles/corrupt/"
+	if err := os.MkdirAll(corruptDirectory, 0755); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial, "location": corruptDirectory}).Warn("Unable to create corrupt profile folder")
+		return
+	}
+
+	target := corruptDirectory + name
+	if common.FileExists(target) {
+		target = fmt.Sprintf("%s.%d", target, time.Now().Unix())
+	}
+
+	if err := os.Rename(profileLocation, target); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial, "location": profileLocation}).Warn("Unable to move corrupt profile")
+		return
 	}
+	logger.Log(logger.Fields{"serial": d.Serial, "location": profileLocation, "target": target}).Warn("Corrupt profile moved to quarantine")
 }
 
-// setAutoRefresh will refresh device data
-func (d *Device) setKeepAlive() {
-	timerKeepAlive = time.NewTicker(time.Duration(deviceKeepAlive) * time.Millisecond)
-	keepAliveChan = make(chan bool)
-	go func() {
-		for {
-			select {
-			case <-timerKeepAlive.C:
-				d.keepAlive()
-			case <-keepAliveChan:
-				timerKeepAlive.Stop()
-				return
-			}
+// migrateDeviceProfile will upgrade device profile saved by older version.
+// Fields added after profile was saved are decoded as zero values and are replaced with defaults.
+func (d *Device) migrateDeviceProfile(pf *DeviceProfile) {
+	// Version 1
+	if pf.Version < 1 {
+		if len(pf.Layout) == 0 {
+			pf.Layout = "US"
 		}
-	}()
+		if pf.ControlDial == 0 {
+			pf.ControlDial = 1 // Disabled control dial didn't exist before version 1
+		}
+		if pf.BrightnessLevel == 0 {
+			pf.BrightnessLevel = 1000
+		}
+		if pf.DialVolumeStep == 0 {
+			pf.DialVolumeStep = defaultVolumeStep
+		}
+		if pf.DialAcceleration == 0 {
+			pf.DialAcceleration = defaultDialAcceleration
+		}
+		if pf.SleepMode == 0 {
+			pf.SleepMode = 15
+		}
+	}
+
+	// Version 2
+	if pf.Version < 2 {
+		// Control dial mode is split to an action of every dial gesture
+		pf.DialBindings = ControlDialBindings{
+			RotateAction:    pf.ControlDial,
+			ClickAction:     dialClickActions[pf.ControlDial],
+			LongPressAction: pf.DialLongPress,
+		}
+	}
+	pf.Version = profileVersion
 }
 
-// setAutoRefresh will refresh device data
-func (d *Device) setAutoRefresh() {
-	timer = time.NewTicker(time.Duration(deviceRefreshInterval) * time.Millisecond)
-	authRefreshChan = make(chan bool)
-	go func() {
-		for {
-			select {
-			case <-timer.C:
+// validateLayout will reset unsupported profile layout to US. Keyboards of other devices or unsupported layouts are
+// replaced too, so packet indexes always belong to a keyboard supported by the device
+func (d *Device) validateLayout(pf *DeviceProfile, profileLocation string) {
+	if pf == nil || len(d.Layouts) == 0 {
+		return
+	}
+
+	if !slices.Contains(d.Layouts, pf.Layout) {
+		logger.Log(logger.Fields{"location": profileLocation, "serial": d.Serial, "layout": pf.Layout}).Warn("Unsupported keyboard layout in profile. Layout is reset to US")
+		pf.Layout = "US"
+	}
+
+	for name, keyboard := range pf.Keyboards {
+		if keyboard != nil && keyboard.Key == keyboardKey && slices.Contains(d.Layouts, keyboard.Layout) {
+			continue
+		}
+
+		replacement := keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, pf.Layout))
+		if replacement == nil {
+			continue
+		}
+		pf.Keyboards[name] = replacement.Clone() // Every replaced profile gets its own rows
+		logger.Log(logger.Fields{"location": profileLocation, "serial": d.Serial, "profile": name}).Warn("Unsupported keyboard in profile, profile colors are reset")
+	}
+}
+
+// applyPhysicalLayout will replace profile keyboards whose physical layout doesn't match the selected layout.
+// ISO and ANSI keyboards have a different key set and packet indexes, so such keyboard data can't be reused
+func (d *Device) applyPhysicalLayout(pf *DeviceProfile) {
+	if pf == nil || len(pf.Layout) == 0 {
+		return
+	}
+
+	layoutKey := fmt.Sprintf("%s-%s", keyboardKey, pf.Layout)
+	physicalLayout := keyboards.GetPhysicalLayout(layoutKey)
+	if len(physicalLayout) == 0 {
+		return
+	}
+	pf.PhysicalLayout = physicalLayout
+
+	for name, keyboard := range pf.Keyboards {
+		if keyboard == nil {
+			continue
+		}
+
+		current := keyboard.PhysicalLayout
+		if len(current) == 0 {
+			current = keyboards.GetPhysicalLayout(fmt.Sprintf("%s-%s", keyboard.Key, keyboard.Layout))
+		}
+		if current == physicalLayout {
+			keyboard.PhysicalLayout = current
+			continue
+		}
+
+		replacement := keyboards.GetKeyboard(layoutKey)
+		if replacement == nil {
+			continue
+		}
+		pf.Keyboards[name] = replacement.Clone() // GetKeyboard copy shares rows with the layout template
+		logger.Log(logger.Fields{"serial": d.Serial, "profile": name, "from": current, "to": physicalLayout}).Warn("Keyboard physical layout changed, profile colors are reset")
+	}
+}
+
+// getDeviceProfile will load persistent device configuration
+func (d *Device) getDeviceProfile() {
+	if len(d.UserProfiles) == 0 {
+		logger.Log(logger.Fields{"serial": d.Serial}).Warn("No profile found for device. Probably initial start")
+	} else {
+		for _, pf := range d.UserProfiles {
+			if pf.Active {
+				d.DeviceProfile = pf
+			}
+		}
+	}
+}
+
+// playStartupEffect will show startup RGB effect from config before saved RGB mode is applied.
+// Effect runs as a preview, so device profile is never changed. Saved RGB mode is restored once effect
+// duration passes or by the first color change, whichever comes first
+func (d *Device) playStartupEffect() {
+	effect := config.GetConfig().StartupEffect
+	if len(effect) == 0 || d.DeviceProfile == nil {
+		return
+	}
+
+	if d.PreviewRgbProfile(effect) != 1 {
+		logger.Log(logger.Fields{"serial": d.Serial, "effect": effect}).Warn("Unable to play startup effect")
+		return
+	}
+	d.setBrightnessLevel()
+
+	duration := common.Clamp(config.GetConfig().StartupDurationMs, 0, maxStartupDuration)
+	d.mutexRgb.Lock()
+	d.startupEffect = time.AfterFunc(time.Duration(duration)*time.Millisecond, d.endStartupEffect)
+	d.mutexRgb.Unlock()
+}
+
+// endStartupEffect will restore saved RGB mode once startup effect duration passes
+func (d *Device) endStartupEffect() {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	if !d.cancelStartupEffect() {
+		return // Color change has already replaced startup effect
+	}
+	d.endPreview()
+	d.stopRgb()        // Exit startup effect
+	d.setDeviceColor() // Saved RGB mode
+}
+
+// cancelStartupEffect will cancel pending end of startup effect. Returns true if startup effect was playing
+func (d *Device) cancelStartupEffect() bool {
+	d.mutexRgb.Lock()
+	defer d.mutexRgb.Unlock()
+
+	if d.startupEffect == nil {
+		return false
+	}
+	d.startupEffect.Stop()
+	d.startupEffect = nil
+	return true
+}
+
+// setBootProfile will activate configured boot profile instead of previously active one
+func (d *Device) setBootProfile() {
+	if d.DeviceProfile == nil || len(d.DeviceProfile.BootProfile) == 0 {
+		return
+	}
+
+	bootProfile := d.DeviceProfile.BootProfile
+	if _, ok := d.UserProfiles[bootProfile]; !ok {
+		logger.Log(logger.Fields{"serial": d.Serial, "profile": bootProfile}).Warn("Boot profile does not exist. Using default profile")
+		bootProfile = "default"
+	}
+
+	profile, ok := d.UserProfiles[bootProfile]
+	if !ok || profile == d.DeviceProfile {
+		return
+	}
+
+	// Both profiles are written right away, delayed save would only write the latter
+	currentProfile := d.DeviceProfile
+	currentProfile.Active = false
+	d.writeDeviceProfile()
+
+	profile.Active = true
+	profile.BootProfile = currentProfile.BootProfile
+	d.DeviceProfile = profile
+	d.writeDeviceProfile()
+	logger.Log(logger.Fields{"serial": d.Serial, "profile": bootProfile}).Info("Activated boot profile")
+}
+
+// SelfTest will verify that dongle and keyboard respond to firmware read, software mode and keepalive commands
+func (d *Device) SelfTest() error {
+	if d.Simulate {
+		return nil // Simulated device has no hardware to test
+	}
+
+	targets := []struct {
+		name    string
+		command byte
+	}{
+		{name: "dongle", command: byte(cmdDongle)},
+		{name: "keyboard", command: byte(cmdKeyboard)},
+	}
+
+	for _, target := range targets {
+		fw, err := d.transfer(cmdGetFirmware, nil, target.command)
+		if err != nil {
+			return fmt.Errorf("unable to read %s firmware: %w", target.name, err)
+		}
+		if _, ok := parseFirmware(fw); !ok {
+			return fmt.Errorf("%s returned invalid firmware response", target.name)
+		}
+	}
+
+	if err := d.setSoftwareMode(); err != nil {
+		return fmt.Errorf("unable to confirm software mode: %w", err)
+	}
+
+	for _, target := range targets {
+		if _, err := d.transfer([]byte{0x12}, nil, target.command); err != nil {
+			return fmt.Errorf("%s did not respond to keepalive: %w", target.name, err)
+		}
+	}
+
+	logger.Log(logger.Fields{"serial": d.Serial}).Info("Device self-test passed")
+	return nil
+}
+
+// keepAlive will keep a device alive
+func (d *Device) keepAlive() {
+	_, err := d.transfer([]byte{0x12}, nil, byte(cmdDongle))
+	if err != nil {
+		d.keepAliveFailed(err)
+		return
+	}
+
+	// Dongle keeps answering while keyboard sleeps, keyboard state is reported in response status
+	response, err := d.transfer([]byte{0x12}, nil, byte(cmdKeyboard))
+	if err != nil {
+		d.keepAliveFailed(err)
+		return
+	}
+	d.keepAliveFailures = 0
+
+	if len(response) > 2 && response[2] != 0x00 {
+		if !d.setAsleep(true) {
+			logger.Log(logger.Fields{"serial": d.Serial, "status": response[2]}).Info("Device is asleep")
+		}
+		return
+	}
+
+	if d.setAsleep(false) {
+		// Keyboard can wake up in hardware mode, so software state is restored
+		logger.Log(logger.Fields{"serial": d.Serial}).Info("Device woke up. Restoring software mode")
+		d.restoreState()
+	}
+}
+
+// setAsleep will change keyboard sleep state and return previous state
+func (d *Device) setAsleep(asleep bool) bool {
+	d.mutexSleep.Lock()
+	defer d.mutexSleep.Unlock()
+	previous := d.asleep
+	d.asleep = asleep
+	return previous
+}
+
+// isAsleep will return true if keyboard reported that it is asleep
+func (d *Device) isAsleep() bool {
+	d.mutexSleep.Lock()
+	defer d.mutexSleep.Unlock()
+	return d.asleep
+}
+
+// keepAliveFailed will count keepalive failures and reconnect a device once failures reach the limit
+func (d *Device) keepAliveFailed(err error) {
+	d.keepAliveFailures++
+	logger.Log(logger.Fields{"error": err, "serial": d.Serial, "failures": d.keepAliveFailures}).Error("Unable to write to a device")
+	if d.keepAliveFailures < maxKeepAliveFailures {
+		return
+	}
+
+	d.keepAliveFailures = 0
+	if !d.reconnect() {
+		logger.Log(logger.Fields{"serial": d.Serial}).Error("Unable to reconnect to a device")
+	}
+}
+
+// reconnect will re-open HID device and restore software mode after repeated communication failures
+func (d *Device) reconnect() bool {
+	if d.Simulate {
+		return true
+	}
+
+	logger.Log(logger.Fields{"serial": d.Serial}).Info("Reconnecting to a device")
+	dev, err := hid.OpenPath(d.hidPath)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to re-open HID device")
+		return false
+	}
+
+	// New handle is opened first, so device is never left without a valid handle
+	mutex.Lock()
+	previous := d.dev
+	d.dev = dev
+	mutex.Unlock()
+
+	if previous != nil {
+		if e := previous.Close(); e != nil {
+			logger.Log(logger.Fields{"error": e, "serial": d.Serial}).Warn("Unable to close previous HID device")
+		}
+	}
+
+	if !d.restoreState() {
+		return false
+	}
+	d.setAsleep(false)
+	logger.Log(logger.Fields{"serial": d.Serial}).Info("Device reconnected")
+	return true
+}
+
+// restoreState will put a device back to software mode and restore LEDs, brightness and RGB mode
+func (d *Device) restoreState() bool {
+	if err := d.setSoftwareMode(); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to change device mode")
+		return false
+	}
+	if err := d.initLeds(); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to initialize LED ports")
+		return false
+	}
+
+	// RGB reset
+	d.stopRgb() // Exit current RGB mode
+	d.setDeviceColor()
+	d.setBrightnessLevel()
+	return true
+}
+
+// setKeepAlive will periodically keep a device alive
+func (d *Device) setKeepAlive() {
+	d.timerKeepAlive = time.NewTicker(time.Duration(d.KeepAliveInterval) * time.Millisecond)
+	d.keepAliveChan = make(chan bool, 1)
+	go func() {
+		for {
+			select {
+			case <-d.timerKeepAlive.C:
+				d.keepAlive()
+			case <-d.keepAliveChan:
+				d.timerKeepAlive.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// stopKeepAlive will stop keepalive. It doesn't block when keepalive goroutine is not running
+func (d *Device) stopKeepAlive() {
+	if d.timerKeepAlive != nil {
+		d.timerKeepAlive.Stop()
+	}
+
+	select {
+	case d.keepAliveChan <- true:
+	default: // Keepalive was never started or stop is already pending
+	}
+}
+
+// setAutoRefresh will refresh device data
+func (d *Device) setAutoRefresh() {
+	interval := temperatures.GetPollInterval()
+	d.timerRefresh = time.NewTicker(interval)
+	d.autoRefreshChan = make(chan bool, 1)
+	go func() {
+		for {
+			select {
+			case <-d.timerRefresh.C:
 				d.setTemperatures()
-			case <-authRefreshChan:
-				timer.Stop()
+				// Polling interval can be changed at runtime
+				if current := temperatures.GetPollInterval(); current != interval {
+					interval = current
+					d.timerRefresh.Reset(interval)
+				}
+			case <-d.autoRefreshChan:
+				d.timerRefresh.Stop()
 				return
 			}
 		}
 	}()
 }
 
+// stopAutoRefresh will stop device data refresh. It doesn't block when refresh goroutine is not running
+func (d *Device) stopAutoRefresh() {
+	if d.timerRefresh != nil {
+		d.timerRefresh.Stop()
+	}
+
+	select {
+	case d.autoRefreshChan <- true:
+	default: // Refresh was never started or stop is already pending
+	}
+}
+
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // setSleepTimer will set device sleep timer
@@ -624,6 +1565,39 @@ func (d *Device) UpdateSleepTimer(minutes int) uint8 {
 	return 0
 }
 
+// UpdateProfileMetadata will update description and tags of active user profile. Tags are trimmed and
+// duplicates are removed, empty description and tags clear metadata
+func (d *Device) UpdateProfileMetadata(description string, tags []string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	description = strings.TrimSpace(description)
+	if len(description) > maxDescriptionLength {
+		return 2
+	}
+
+	var cleanTags []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if len(tag) == 0 || slices.ContainsFunc(cleanTags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return 3
+		}
+		cleanTags = append(cleanTags, tag)
+	}
+	if len(cleanTags) > maxTags {
+		return 3
+	}
+
+	d.DeviceProfile.Description = description
+	d.DeviceProfile.Tags = cleanTags
+	d.saveDeviceProfile()
+	return 1
+}
+
 // UpdateDeviceLabel will set / update device label
 func (d *Device) UpdateDeviceLabel(_ int, label string) uint8 {
 	mutex.Lock()
@@ -641,84 +1615,235 @@ func (d *Device) UpdateRgbProfile(_ int, profile string) uint8 {
 		return 0
 	}
 
+	d.previewActive = false              // Saved RGB mode replaces the preview
 	d.DeviceProfile.RGBProfile = profile // Set profile
 	d.saveDeviceProfile()                // Save profile
-	if d.activeRgb != nil {
-		d.activeRgb.Exit <- true // Exit current RGB mode
-		d.activeRgb = nil
+	d.stopRgb()                          // Exit current RGB mode
+	d.setDeviceColor()                   // Restart RGB
+	return 1
+
+}
+
+// PreviewRgbProfile will start RGB mode without saving it to device profile
+func (d *Device) PreviewRgbProfile(profile string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if _, ok := d.RGBModes[profile]; !ok {
+		logger.Log(logger.Fields{"serial": d.Serial, "profile": profile}).Warn("Non-existing RGB profile")
+		return 2
 	}
+
+	if !d.previewActive {
+		d.previewRgbProfile = d.DeviceProfile.RGBProfile
+		d.previewActive = true
+	}
+
+	d.DeviceProfile.RGBProfile = profile
+	d.stopRgb()        // Exit current RGB mode
+	d.setDeviceColor() // Restart RGB
+	return 1
+}
+
+// CancelPreview will stop RGB mode preview and restore saved RGB mode
+func (d *Device) CancelPreview() uint8 {
+	if !d.endPreview() {
+		return 2
+	}
+
+	d.stopRgb()        // Exit current RGB mode
 	d.setDeviceColor() // Restart RGB
 	return 1
+}
+
+// endPreview will restore saved RGB mode in device profile. Returns false if preview is not active
+func (d *Device) endPreview() bool {
+	if !d.previewActive {
+		return false
+	}
 
+	if d.DeviceProfile != nil {
+		d.DeviceProfile.RGBProfile = d.previewRgbProfile
+	}
+	d.previewActive = false
+	d.previewRgbProfile = ""
+	return true
 }
 
 // ChangeDeviceBrightness will change device brightness
 func (d *Device) ChangeDeviceBrightness(mode uint8) uint8 {
 	d.DeviceProfile.Brightness = mode
-	d.DeviceProfile.BrightnessLevel = 1000
-
 	if mode == 4 {
-		d.DeviceProfile.BrightnessLevel = 0
+		d.storeBrightnessLevel(0)
+	} else {
+		d.storeBrightnessLevel(1000)
 	}
 
 	d.saveDeviceProfile()
-	if d.activeRgb != nil {
-		d.activeRgb.Exit <- true // Exit current RGB mode
-		d.activeRgb = nil
+	d.stopRgb()              // Exit current RGB mode
+	d.setDeviceColor()       // Restart RGB
+	d.writeBrightnessLevel() // Brightness
+	return 1
+}
+
+// SetBrightnessPercent will change global brightness level via percentage from 0 - 100.
+// Percentage drives the same hardware brightness level as the control dial, brightness mode is not changed
+func (d *Device) SetBrightnessPercent(pct uint8) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
 	}
-	d.setDeviceColor() // Restart RGB
-	buf := make([]byte, 2)
-	binary.LittleEndian.PutUint16(buf[0:2], d.DeviceProfile.BrightnessLevel)
-	_, err := d.transfer(cmdBrightness, buf, byte(cmdKeyboard))
-	if err != nil {
-		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to change brightness")
+
+	if pct > 100 {
+		return 2
+	}
+	return d.SetBrightnessLevel(uint16(pct) * 10)
+}
+
+// SetBrightnessLevel will change device brightness level with full hardware resolution, 0-1000
+func (d *Device) SetBrightnessLevel(level uint16) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if level > 1000 {
+		return 2
 	}
+
+	d.DeviceProfile.BrightnessLevel = level
+	d.saveDeviceProfile()
+	d.setBrightnessLevel() // Send it
 	return 1
 }
 
 // ChangeDeviceProfile will change device profile
-func (d *Device) ChangeDeviceProfile(profileName string) uint8 {
+func (d *Device) ChangeDeviceProfile(profileName string) common.ResultCode {
 	if profile, ok := d.UserProfiles[profileName]; ok {
-		currentProfile := d.DeviceProfile
-		currentProfile.Active = false
-		d.DeviceProfile = currentProfile
-		d.saveDeviceProfile()
+		if profile == nil || d.DeviceProfile == nil {
+			return common.Failure
+		}
+		d.endPreview()              // Preview belongs to current profile
+		d.cancelDeviceProfileSave() // Current profile is written below, pending save would mark it active again
 
-		// RGB reset
-		if d.activeRgb != nil {
-			d.activeRgb.Exit <- true // Exit current RGB mode
-			d.activeRgb = nil
+		// DeviceProfile points to one of UserProfiles, so both profiles are changed as copies
+		// and every profile file is written only once
+		currentProfile := *d.DeviceProfile
+		currentProfile.Active = false
+		if len(currentProfile.Path) < 1 {
+			currentProfile.Path = pwd + "/database/profiles/" + d.Serial + ".json"
 		}
 
-		newProfile := profile
+		newProfile := *profile
 		newProfile.Active = true
-		d.DeviceProfile = newProfile
-		d.saveDeviceProfile()
+		newProfile.BootProfile = currentProfile.BootProfile
+		if len(newProfile.Path) < 1 {
+			logger.Log(logger.Fields{"serial": d.Serial, "profile": profileName}).Error("Unable to change profile. Profile has no path")
+			return common.Failure
+		}
+
+		if currentProfile.Path != newProfile.Path {
+			if err := d.writeProfileFile(&currentProfile); err != nil {
+				return common.Failure
+			}
+		}
+		if err := d.writeProfileFile(&newProfile); err != nil {
+			return common.Failure
+		}
+
+		// RGB reset
+		d.stopRgb() // Exit current RGB mode
+		d.storeUserProfile(&currentProfile)
+		d.storeUserProfile(&newProfile) // New profile is now active
 		d.setDeviceColor()
-		return 1
-	}
-	return 0
+		d.setBrightnessLevel()
+		d.setControlDialListener()
+		d.resetIdleTimer() // Idle timeout belongs to the profile
+		d.checkNightMode() // Night mode schedule belongs to the profile
+		d.notifyProfileChange(profileName)
+		return common.Success
+	}
+	return common.Failure
 }
 
-// ChangeKeyboardLayout will change keyboard layout
-func (d *Device) ChangeKeyboardLayout(layout string) uint8 {
-	layouts := keyboards.GetLayouts(keyboardKey)
-	if len(layouts) < 1 {
-		return 2
+// ReloadProfiles will reload device profiles from disk. Active profile is re-applied if it was changed on disk
+func (d *Device) ReloadProfiles() uint8 {
+	d.endPreview()                 // Preview is not stored on disk
+	previous := d.marshalProfile() // Pending save is flushed by loadDeviceProfiles before profiles are read
+	d.loadDeviceProfiles()
+	if d.DeviceProfile == nil {
+		return 0
 	}
+	current := d.marshalProfile()
 
-	if slices.Contains(layouts, layout) {
-		if d.DeviceProfile != nil {
-			if _, ok := d.DeviceProfile.Keyboards["default"]; ok {
-				layoutKey := fmt.Sprintf("%s-%s", keyboardKey, layout)
-				keyboardLayout := keyboards.GetKeyboard(layoutKey)
+	if bytes.Equal(previous, current) {
+		return 1
+	}
+
+	logger.Log(logger.Fields{"serial": d.Serial, "profile": d.DeviceProfile.Profile}).Info("Active profile changed on disk, applying")
+	d.stopRgb() // Exit current RGB mode
+	d.setDeviceColor()
+	d.setBrightnessLevel()
+	d.setControlDialListener()
+	return 1
+}
+
+// marshalProfile will return JSON data of active device profile, or nil when device has no profile
+func (d *Device) marshalProfile() []byte {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+	if d.DeviceProfile == nil {
+		return nil
+	}
+	data, _ := json.Marshal(d.DeviceProfile)
+	return data
+}
+
+// RegisterProfileChangeHandler will register a handler called after active profile is changed
+func (d *Device) RegisterProfileChangeHandler(handler func(serial, profile string)) {
+	d.mutexHandlers.Lock()
+	defer d.mutexHandlers.Unlock()
+	d.profileHandlers = append(d.profileHandlers, handler)
+}
+
+// RegisterUnplugHandler will register a handler called after unplugged device is released
+func (d *Device) RegisterUnplugHandler(handler func(serial string)) {
+	d.mutexHandlers.Lock()
+	defer d.mutexHandlers.Unlock()
+	d.unplugHandlers = append(d.unplugHandlers, handler)
+}
+
+// notifyProfileChange will call all registered profile change handlers.
+// Handlers run in their own goroutine, so a slow handler can not block the device.
+func (d *Device) notifyProfileChange(profile string) {
+	d.mutexHandlers.Lock()
+	handlers := make([]func(serial, profile string), len(d.profileHandlers))
+	copy(handlers, d.profileHandlers)
+	d.mutexHandlers.Unlock()
+
+	for _, handler := range handlers {
+		go handler(d.Serial, profile)
+	}
+}
+
+// ChangeKeyboardLayout will change keyboard layout
+func (d *Device) ChangeKeyboardLayout(layout string) uint8 {
+	if len(d.Layouts) < 1 {
+		return 2
+	}
+
+	if slices.Contains(d.Layouts, layout) {
+		if d.DeviceProfile != nil {
+			if _, ok := d.DeviceProfile.Keyboards["default"]; ok {
+				layoutKey := fmt.Sprintf("%s-%s", keyboardKey, layout)
+				keyboardLayout := keyboards.GetKeyboard(layoutKey)
 				if keyboardLayout == nil {
 					logger.Log(logger.Fields{"serial": d.Serial}).Error("Trying to apply non-existing keyboard layout")
 					return 2
 				}
 
-				d.DeviceProfile.Keyboards["default"] = keyboardLayout
+				d.DeviceProfile.Keyboards["default"] = keyboardLayout.Clone() // GetKeyboard copy shares rows with the layout template
 				d.DeviceProfile.Layout = layout
+				d.applyPhysicalLayout(d.DeviceProfile)
 				d.saveDeviceProfile()
 				return 1
 			}
@@ -733,6 +1858,19 @@ func (d *Device) ChangeKeyboardLayout(layout string) uint8 {
 	return 0
 }
 
+// GetCurrentLayout will return keyboard layout of a device
+func (d *Device) GetCurrentLayout() string {
+	if d.DeviceProfile == nil {
+		return ""
+	}
+	return d.DeviceProfile.Layout
+}
+
+// GetAvailableLayouts will return keyboard layouts supported by a device. List is loaded once on device init
+func (d *Device) GetAvailableLayouts() []string {
+	return slices.Clone(d.Layouts)
+}
+
 // getCurrentKeyboard will return current active keyboard
 func (d *Device) getCurrentKeyboard() *keyboards.Keyboard {
 	if keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
@@ -757,7 +1895,7 @@ func (d *Device) SaveDeviceProfile(profileName string, new bool) uint8 {
 		}
 
 		d.DeviceProfile.Profiles = append(d.DeviceProfile.Profiles, profileName)
-		d.DeviceProfile.Keyboards[profileName] = d.getCurrentKeyboard()
+		d.DeviceProfile.Keyboards[profileName] = d.getCurrentKeyboard().Clone() // New profile gets its own rows
 		d.saveDeviceProfile()
 		return 1
 	} else {
@@ -767,133 +1905,1217 @@ func (d *Device) SaveDeviceProfile(profileName string, new bool) uint8 {
 }
 
 // UpdateKeyboardProfile will change keyboard profile
-func (d *Device) UpdateKeyboardProfile(profileName string) uint8 {
+func (d *Device) UpdateKeyboardProfile(profileName string) common.ResultCode {
 	if d.DeviceProfile == nil {
-		return 0
+		return common.Failure
 	}
 
 	if !slices.Contains(d.DeviceProfile.Profiles, profileName) {
-		return 2
+		return common.ProfileNotFound
 	}
 
 	if _, ok := d.DeviceProfile.Keyboards[profileName]; !ok {
-		return 2
+		return common.ProfileNotFound
 	}
 
 	d.DeviceProfile.Profile = profileName
 	d.saveDeviceProfile()
 	// RGB reset
-	if d.activeRgb != nil {
-		d.activeRgb.Exit <- true // Exit current RGB mode
-		d.activeRgb = nil
-	}
+	d.stopRgb() // Exit current RGB mode
 	d.setDeviceColor()
-	return 1
+	d.notifyProfileChange(profileName)
+	return common.Success
 }
 
-// UpdateControlDial will update control dial function
-func (d *Device) UpdateControlDial(value int) uint8 {
-	d.DeviceProfile.ControlDial = value
+// UpdateDialVolumeStep will update volume step in percent used by the control dial
+func (d *Device) UpdateDialVolumeStep(step int) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if step < minVolumeStep || step > maxVolumeStep {
+		return 2
+	}
+
+	if !inputmanager.IsVolumeStepSupported(step) {
+		return 3 // Volume keys can't change volume by this step
+	}
+
+	d.DeviceProfile.DialVolumeStep = step
 	d.saveDeviceProfile()
 	return 1
 }
 
-// DeleteKeyboardProfile will delete keyboard profile
-func (d *Device) DeleteKeyboardProfile(profileName string) uint8 {
+// UpdateDialAcceleration will update brightness dial acceleration factor. Factor of 1 disables acceleration
+func (d *Device) UpdateDialAcceleration(factor int) uint8 {
 	if d.DeviceProfile == nil {
 		return 0
 	}
 
-	if profileName == "default" {
-		return 3
+	if factor < minDialAcceleration || factor > maxDialAcceleration {
+		return 2
 	}
 
-	if !slices.Contains(d.DeviceProfile.Profiles, profileName) {
-		return 2
+	d.DeviceProfile.DialAcceleration = factor
+	d.saveDeviceProfile()
+	return 1
+}
+
+// UpdateDeviceLcd will report that LCD mode is not supported, since keyboard has no LCD
+func (d *Device) UpdateDeviceLcd(_ int, mode uint8) uint8 {
+	logger.Log(logger.Fields{"serial": d.Serial, "mode": mode}).Info("LCD mode change ignored. Device has no LCD")
+	return 2
+}
+
+// UpdateDeviceLcdRotation will report that LCD rotation is not supported, since keyboard has no LCD
+func (d *Device) UpdateDeviceLcdRotation(_ int, rotation uint8) uint8 {
+	logger.Log(logger.Fields{"serial": d.Serial, "rotation": rotation}).Info("LCD rotation change ignored. Device has no LCD")
+	return 2
+}
+
+// UpdateDialLongPress will update control dial long press action
+func (d *Device) UpdateDialLongPress(action int) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
 	}
 
-	if _, ok := d.DeviceProfile.Keyboards[profileName]; !ok {
+	if _, ok := d.LongPressOptions[action]; !ok {
 		return 2
 	}
 
-	index := common.IndexOfString(d.DeviceProfile.Profiles, profileName)
-	if index < 0 {
+	d.DeviceProfile.DialLongPress = action
+	d.DeviceProfile.DialBindings.LongPressAction = action
+	d.saveDeviceProfile()
+	d.setControlDialListener()
+	return 1
+}
+
+// dialLongPress will run control dial long press action of current profile
+func (d *Device) dialLongPress() {
+	if d.DeviceProfile == nil {
+		return
+	}
+	d.runDialPressAction(d.DeviceProfile.DialBindings.LongPressAction)
+}
+
+// runDialPressAction will run control dial click or long press action
+func (d *Device) runDialPressAction(action int) {
+	switch action {
+	case dialLongPressMute:
+		d.toggleMute()
+	case dialLongPressProfile:
+		d.switchKeyboardProfile(true)
+	case dialLongPressBrightness:
+		if d.getBrightnessLevel() > 0 {
+			d.storeBrightnessLevel(0)
+		} else {
+			d.storeBrightnessLevel(1000)
+		}
+		d.saveDeviceProfile()
+		d.writeBrightnessLevel() // Send it
+	}
+}
+
+// UpdateTemperatureRange will update device temperature range used by temperature RGB modes.
+// Zero range removes device override and RGB profile range is used again
+func (d *Device) UpdateTemperatureRange(minTemp, maxTemp float64) uint8 {
+	if d.DeviceProfile == nil {
 		return 0
 	}
 
-	d.DeviceProfile.Profile = "default"
-	d.DeviceProfile.Profiles = append(d.DeviceProfile.Profiles[:index], d.DeviceProfile.Profiles[index+1:]...)
-	delete(d.DeviceProfile.Keyboards, profileName)
+	if (minTemp != 0 || maxTemp != 0) && minTemp >= maxTemp {
+		return 2
+	}
 
+	d.DeviceProfile.TempMin = minTemp
+	d.DeviceProfile.TempMax = maxTemp
 	d.saveDeviceProfile()
-	// RGB reset
-	if d.activeRgb != nil {
-		d.activeRgb.Exit <- true // Exit current RGB mode
-		d.activeRgb = nil
+
+	if d.DeviceProfile.RGBProfile == "cpu-temperature" {
+		d.stopRgb()        // Exit current RGB mode
+		d.setDeviceColor() // Restart RGB
 	}
-	d.setDeviceColor()
 	return 1
 }
 
-// SaveUserProfile will generate a new user profile configuration and save it to a file
-func (d *Device) SaveUserProfile(profileName string) uint8 {
-	if d.DeviceProfile != nil {
-		profilePath := pwd + "/database/profiles/" + d.Serial + "-" + profileName + ".json"
+// getTemperatureRange will return temperature range for temperature RGB modes. Device range overrides RGB profile range
+func (d *Device) getTemperatureRange(profile *rgb.Profile) (float64, float64) {
+	if d.DeviceProfile != nil && d.DeviceProfile.TempMax > d.DeviceProfile.TempMin {
+		return d.DeviceProfile.TempMin, d.DeviceProfile.TempMax
+	}
+	return profile.MinTemp, profile.MaxTemp
+}
 
-		newProfile := d.DeviceProfile
-		newProfile.Path = profilePath
-		newProfile.Active = false
+// UpdateColorOrder will update color byte order for device revisions with swapped color channels
+func (d *Device) UpdateColorOrder(order string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
 
-		buffer, err := json.Marshal(newProfile)
-		if err != nil {
-			logger.Log(logger.Fields{"error": err}).Error("Unable to convert to json format")
-			return 0
+	if !rgb.IsValidColorOrder(order) {
+		return 2
+	}
+
+	d.DeviceProfile.ColorOrder = order
+	d.saveDeviceProfile()
+
+	d.stopRgb()        // Exit current RGB mode
+	d.setDeviceColor() // Restart RGB
+	return 1
+}
+
+// UpdateAppBindings will update keyboard profiles activated by running applications.
+// Fallback profile is activated when no bound application is running, empty fallback restores previous profile
+func (d *Device) UpdateAppBindings(bindings map[string]string, fallback string) common.ResultCode {
+	if d.DeviceProfile == nil {
+		return common.Failure
+	}
+
+	appBindings := make(map[string]string, len(bindings))
+	for executable, profileName := range bindings {
+		executable = strings.TrimSpace(executable)
+		if len(executable) == 0 || strings.ContainsAny(executable, "/\\") {
+			return common.InvalidInput
 		}
+		if !slices.Contains(d.DeviceProfile.Profiles, profileName) {
+			return common.ProfileNotFound
+		}
+		appBindings[executable] = profileName
+	}
 
-		// Create profile filename
-		file, err := os.Create(profilePath)
-		if err != nil {
-			logger.Log(logger.Fields{"error": err, "location": newProfile.Path}).Error("Unable to create new device profile")
-			return 0
+	if len(fallback) > 0 && !slices.Contains(d.DeviceProfile.Profiles, fallback) {
+		return common.ProfileNotFound
+	}
+
+	d.DeviceProfile.AppBindings = appBindings
+	d.DeviceProfile.AppFallback = fallback
+	d.saveDeviceProfile()
+	return common.Success
+}
+
+// setAppWatcher will periodically activate keyboard profiles bound to running applications
+func (d *Device) setAppWatcher() {
+	exit := make(chan bool)
+	d.appWatcherExit = exit
+	go func() {
+		ticker := time.NewTicker(time.Duration(appWatchInterval) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.watchApps()
+			case <-exit:
+				return
+			}
 		}
+	}()
+}
 
-		_, err = file.Write(buffer)
-		if err != nil {
-			logger.Log(logger.Fields{"error": err, "location": newProfile.Path}).Error("Unable to write data")
-			return 0
+// stopAppWatcher will stop application watcher. Channel is closed, so watcher can be stopped from its own profile switch
+func (d *Device) stopAppWatcher() {
+	if d.appWatcherExit != nil {
+		close(d.appWatcherExit)
+		d.appWatcherExit = nil
+	}
+}
+
+// watchApps will switch keyboard profile to the one bound to the most recently started running application
+func (d *Device) watchApps() {
+	if d.DeviceProfile == nil || len(d.DeviceProfile.AppBindings) == 0 {
+		d.appBound = false
+		return
+	}
+
+	processes, err := common.GetRunningProcesses()
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to read running processes")
+		return
+	}
+
+	target := ""
+	var newest uint64
+	for executable, profileName := range d.DeviceProfile.AppBindings {
+		if !slices.Contains(d.DeviceProfile.Profiles, profileName) {
+			continue // Profile was deleted or renamed after binding
 		}
+		if startTime, ok := processes[executable]; ok && (len(target) == 0 || startTime > newest) {
+			target = profileName
+			newest = startTime
+		}
+	}
 
-		err = file.Close()
-		if err != nil {
-			logger.Log(logger.Fields{"error": err, "location": newProfile.Path}).Error("Unable to close file handle")
-			return 0
+	if len(target) == 0 {
+		if !d.appBound {
+			return // Profile was not changed by application binding
 		}
-		d.loadDeviceProfiles()
-		return 1
+		d.appBound = false
+		target = d.DeviceProfile.AppFallback
+		if len(target) == 0 {
+			target = d.appPrevious
+		}
+	} else if !d.appBound {
+		d.appBound = true
+		d.appPrevious = d.DeviceProfile.Profile
+	}
+
+	if len(target) == 0 || target == d.DeviceProfile.Profile {
+		return
+	}
+
+	if result := d.UpdateKeyboardProfile(target); result != common.Success {
+		logger.Log(logger.Fields{"serial": d.Serial, "profile": target, "result": result}).Warn("Unable to activate application profile")
 	}
-	return 0
 }
 
-// UpdateDeviceColor will update device color based on selected input
-func (d *Device) UpdateDeviceColor(keyOption int, color rgb.Color) uint8 {
+// UpdateIdleDimming will update inactivity timeout in seconds and brightness level of idle device.
+// Zero timeout disables idle dimming
+func (d *Device) UpdateIdleDimming(timeout int, level uint16) uint8 {
 	if d.DeviceProfile == nil {
 		return 0
 	}
-	switch keyOption {
-	case 2:
-		{
-			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
-				d.DeviceProfile.Keyboards[d.DeviceProfile.Profile].Color = color
-				if d.activeRgb != nil {
-					d.activeRgb.Exit <- true // Exit current RGB mode
-					d.activeRgb = nil
-				}
-				d.setDeviceColor() // Restart RGB
-				return 1
+
+	if timeout < 0 || timeout > maxIdleTimeout || level > 1000 {
+		return 2
+	}
+
+	d.DeviceProfile.IdleTimeout = timeout
+	d.DeviceProfile.IdleBrightness = level
+	d.saveDeviceProfile()
+	d.setControlDialListener() // Input is detected by control dial listener
+	d.resetIdleTimer()
+	return 1
+}
+
+// resetIdleTimer will restart idle timer and restore brightness of idle device
+func (d *Device) resetIdleTimer() {
+	d.mutexIdle.Lock()
+	defer d.mutexIdle.Unlock()
+
+	d.idleGeneration++ // Cancels fade in progress
+	if d.idleDimmed {
+		d.idleDimmed = false
+		d.writeBrightnessValue(d.capNightBrightness(d.getBrightnessLevel()))
+	}
+
+	if d.DeviceProfile == nil || d.DeviceProfile.IdleTimeout <= 0 {
+		if d.idleTimer != nil {
+			d.idleTimer.Stop()
+			d.idleTimer = nil
+		}
+		return
+	}
+
+	timeout := time.Duration(d.DeviceProfile.IdleTimeout) * time.Second
+	if d.idleTimer == nil {
+		d.idleTimer = time.AfterFunc(timeout, d.idleDim)
+	} else {
+		d.idleTimer.Reset(timeout)
+	}
+}
+
+// cancelIdleTimer will stop idle timer and fade in progress
+func (d *Device) cancelIdleTimer() {
+	d.mutexIdle.Lock()
+	defer d.mutexIdle.Unlock()
+
+	d.idleGeneration++
+	if d.idleTimer != nil {
+		d.idleTimer.Stop()
+		d.idleTimer = nil
+	}
+}
+
+// idleDim will gradually dim device brightness down to idle brightness level
+func (d *Device) idleDim() {
+	d.mutexIdle.Lock()
+	if d.idleDimmed || d.DeviceProfile == nil || d.DeviceProfile.IdleTimeout <= 0 {
+		d.mutexIdle.Unlock()
+		return
+	}
+	d.idleDimmed = true
+	generation := d.idleGeneration
+	floor := d.DeviceProfile.IdleBrightness
+	d.mutexIdle.Unlock()
+
+	level := d.capNightBrightness(d.getBrightnessLevel())
+	if level <= floor {
+		return
+	}
+
+	for step := 1; step <= idleFadeSteps; step++ {
+		if step > 1 {
+			time.Sleep(time.Duration(idleFadeInterval) * time.Millisecond)
+		}
+
+		// Written under mutexIdle, so fade step can't overwrite restored brightness
+		d.mutexIdle.Lock()
+		if d.idleGeneration != generation {
+			d.mutexIdle.Unlock()
+			return // Input detected, brightness is already restored
+		}
+		d.writeBrightnessValue(level - uint16(int(level-floor)*step/idleFadeSteps))
+		d.mutexIdle.Unlock()
+	}
+}
+
+// UpdateMirrorTarget will update serial of a device mirroring RGB changes of this device. Empty serial disables mirroring.
+// Target existence and mirror loops are validated by devices package, which knows all managed devices
+func (d *Device) UpdateMirrorTarget(serial string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if serial == d.Serial {
+		return 2
+	}
+
+	d.DeviceProfile.MirrorTarget = serial
+	d.saveDeviceProfile()
+	return 1
+}
+
+// GetMirrorTarget will return serial of a device mirroring RGB changes of this device
+func (d *Device) GetMirrorTarget() string {
+	if d.DeviceProfile == nil {
+		return ""
+	}
+	return d.DeviceProfile.MirrorTarget
+}
+
+// UpdateNightMode will update night mode schedule. During night mode a device shows dim warm color instead of
+// its RGB profile. Start later than end means night mode ends on the next day
+func (d *Device) UpdateNightMode(enabled bool, start, end string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	startTime, err := time.Parse(nightModeLayout, start)
+	if err != nil {
+		return 2
+	}
+	endTime, err := time.Parse(nightModeLayout, end)
+	if err != nil {
+		return 2
+	}
+
+	if startTime.Equal(endTime) {
+		return 3
+	}
+
+	d.DeviceProfile.NightMode = enabled
+	d.DeviceProfile.NightStart = startTime.Format(nightModeLayout)
+	d.DeviceProfile.NightEnd = endTime.Format(nightModeLayout)
+	d.saveDeviceProfile()
+	d.checkNightMode()
+	return 1
+}
+
+// setNightMode will periodically check night mode schedule
+func (d *Device) setNightMode() {
+	exit := make(chan bool)
+	d.nightExit = exit
+	d.checkNightMode()
+	go func() {
+		ticker := time.NewTicker(time.Duration(nightModeInterval) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.checkNightMode()
+			case <-exit:
+				return
 			}
 		}
+	}()
+}
+
+// stopNightMode will stop night mode schedule check
+func (d *Device) stopNightMode() {
+	if d.nightExit != nil {
+		close(d.nightExit)
+		d.nightExit = nil
+	}
+}
+
+// checkNightMode will start or end night mode based on current time
+func (d *Device) checkNightMode() {
+	active := d.isNightTime(time.Now())
+
+	d.mutexNight.Lock()
+	if d.nightActive == active {
+		d.mutexNight.Unlock()
+		return
+	}
+	d.nightActive = active
+	d.mutexNight.Unlock()
+
+	if active {
+		logger.Log(logger.Fields{"serial": d.Serial}).Info("Night mode started")
+	} else {
+		logger.Log(logger.Fields{"serial": d.Serial}).Info("Night mode ended")
+	}
+
+	// RGB reset, setDeviceColor applies night color or restores RGB profile
+	d.stopRgb() // Exit current RGB mode
+	d.setDeviceColor()
+	d.writeBrightnessLevel()
+}
+
+// isNightTime will return true when a given time is within night mode schedule
+func (d *Device) isNightTime(now time.Time) bool {
+	if d.DeviceProfile == nil || !d.DeviceProfile.NightMode {
+		return false
+	}
+
+	start, err := time.Parse(nightModeLayout, d.DeviceProfile.NightStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(nightModeLayout, d.DeviceProfile.NightEnd)
+	if err != nil {
+		return false
+	}
+
+	current := now.Hour()*60 + now.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return current >= from && current < to
+	}
+	return current >= from || current < to // Schedule wraps around midnight
+}
+
+// isNightMode will return true while night mode is active
+func (d *Device) isNightMode() bool {
+	d.mutexNight.Lock()
+	defer d.mutexNight.Unlock()
+	return d.nightActive
+}
+
+// capNightBrightness will limit brightness level while night mode is active
+func (d *Device) capNightBrightness(level uint16) uint16 {
+	if d.isNightMode() && level > uint16(nightModeBrightness) {
+		return uint16(nightModeBrightness)
+	}
+	return level
+}
+
+// getColorOrder will return color byte order of a device
+func (d *Device) getColorOrder() string {
+	if d.DeviceProfile == nil || len(d.DeviceProfile.ColorOrder) == 0 {
+		return rgb.ColorOrderRGB
+	}
+	return d.DeviceProfile.ColorOrder
+}
+
+// UpdateDialInvert will update control dial rotation direction for volume and brightness
+func (d *Device) UpdateDialInvert(invert bool) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	d.DeviceProfile.DialInvert = invert
+	d.saveDeviceProfile()
+	return 1
+}
+
+// isDialIncrease will return true if dial rotation value should increase volume or brightness.
+// Value of 1 is a clockwise step, 255 is a counter-clockwise step
+func (d *Device) isDialIncrease(value byte) bool {
+	increase := value == 1
+	if d.DeviceProfile != nil && d.DeviceProfile.DialInvert {
+		return !increase
+	}
+	return increase
+}
+
+// getBrightnessStep will return brightness change for a dial tick.
+// Consecutive fast ticks multiply the step up to acceleration factor, slow ticks always use base step
+func (d *Device) getBrightnessStep(ticks int) int {
+	factor := defaultDialAcceleration
+	if d.DeviceProfile != nil && d.DeviceProfile.DialAcceleration > 0 {
+		factor = d.DeviceProfile.DialAcceleration
+	}
+	return brightnessStep * common.Clamp(ticks, 1, factor)
+}
+
+// switchKeyboardProfile will activate next or previous keyboard profile
+func (d *Device) switchKeyboardProfile(forward bool) {
+	if d.DeviceProfile == nil || len(d.DeviceProfile.Profiles) < 2 {
+		return
+	}
+
+	profiles := d.DeviceProfile.Profiles
+	index := common.IndexOfString(profiles, d.DeviceProfile.Profile)
+	if index < 0 {
+		index = 0
+	} else if forward {
+		index = (index + 1) % len(profiles)
+	} else {
+		index = (index - 1 + len(profiles)) % len(profiles)
+	}
+	d.UpdateKeyboardProfile(profiles[index])
+}
+
+// UpdateControlDial will update control dial mode. Mode sets rotate action and its matching click action
+func (d *Device) UpdateControlDial(value int) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if _, ok := d.ControlDialOptions[value]; !ok {
+		return 2
+	}
+
+	d.DeviceProfile.ControlDial = value
+	d.DeviceProfile.DialBindings.RotateAction = value
+	d.DeviceProfile.DialBindings.ClickAction = dialClickActions[value]
+	d.saveDeviceProfile()
+	d.setControlDialListener()
+	return 1
+}
+
+// UpdateDialRotateAction will update action of control dial rotation
+func (d *Device) UpdateDialRotateAction(action int) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if _, ok := d.ControlDialOptions[action]; !ok {
+		return 2
+	}
+
+	d.DeviceProfile.ControlDial = action
+	d.DeviceProfile.DialBindings.RotateAction = action
+	d.saveDeviceProfile()
+	d.setControlDialListener()
+	return 1
+}
+
+// UpdateDialClickAction will update action of control dial click
+func (d *Device) UpdateDialClickAction(action int) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if _, ok := d.ClickOptions[action]; !ok {
+		return 2
+	}
+
+	d.DeviceProfile.DialBindings.ClickAction = action
+	d.saveDeviceProfile()
+	d.setControlDialListener()
+	return 1
+}
+
+// DeleteKeyboardProfile will delete keyboard profile
+func (d *Device) DeleteKeyboardProfile(profileName string) common.ResultCode {
+	if d.DeviceProfile == nil {
+		return common.Failure
+	}
+
+	if profileName == "default" {
+		return common.Protected
+	}
+
+	if !slices.Contains(d.DeviceProfile.Profiles, profileName) {
+		return common.ProfileNotFound
+	}
+
+	if _, ok := d.DeviceProfile.Keyboards[profileName]; !ok {
+		return common.ProfileNotFound
+	}
+
+	index := common.IndexOfString(d.DeviceProfile.Profiles, profileName)
+	if index < 0 {
+		return common.Failure
+	}
+
+	d.DeviceProfile.Profile = "default"
+	d.DeviceProfile.Profiles = append(d.DeviceProfile.Profiles[:index], d.DeviceProfile.Profiles[index+1:]...)
+	delete(d.DeviceProfile.Keyboards, profileName)
+
+	d.saveDeviceProfile()
+	// RGB reset
+	d.stopRgb() // Exit current RGB mode
+	d.setDeviceColor()
+	return common.Success
+}
+
+// RenameKeyboardProfile will rename existing keyboard profile
+func (d *Device) RenameKeyboardProfile(oldName, newName string) common.ResultCode {
+	if d.DeviceProfile == nil {
+		return common.Failure
+	}
+
+	if oldName == "default" {
+		return common.Protected
+	}
+
+	if !slices.Contains(d.DeviceProfile.Profiles, oldName) {
+		return common.ProfileNotFound
+	}
+
+	if _, ok := d.DeviceProfile.Keyboards[oldName]; !ok {
+		return common.ProfileNotFound
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", newName); !m {
+		return common.InvalidInput
+	}
+
+	if slices.Contains(d.DeviceProfile.Profiles, newName) {
+		return common.InvalidInput
+	}
+
+	if _, ok := d.DeviceProfile.Keyboards[newName]; ok {
+		return common.InvalidInput
+	}
+
+	index := common.IndexOfString(d.DeviceProfile.Profiles, oldName)
+	if index < 0 {
+		return common.Failure
+	}
+
+	d.DeviceProfile.Profiles[index] = newName
+	d.DeviceProfile.Keyboards[newName] = d.DeviceProfile.Keyboards[oldName]
+	delete(d.DeviceProfile.Keyboards, oldName)
+	if d.DeviceProfile.Profile == oldName {
+		d.DeviceProfile.Profile = newName
+	}
+	d.saveDeviceProfile()
+	return common.Success
+}
+
+// CloneKeyboardProfile will create a new keyboard profile as a copy of existing keyboard profile
+func (d *Device) CloneKeyboardProfile(source, newName string) common.ResultCode {
+	if d.DeviceProfile == nil {
+		return common.Failure
+	}
+
+	keyboard, ok := d.DeviceProfile.Keyboards[source]
+	if !ok || keyboard == nil || !slices.Contains(d.DeviceProfile.Profiles, source) {
+		return common.ProfileNotFound
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", newName); !m {
+		return common.InvalidInput
+	}
+
+	if slices.Contains(d.DeviceProfile.Profiles, newName) {
+		return common.InvalidInput
+	}
+
+	if _, ok = d.DeviceProfile.Keyboards[newName]; ok {
+		return common.InvalidInput
+	}
+
+	d.DeviceProfile.Keyboards[newName] = keyboard.Clone()
+	d.DeviceProfile.Profiles = append(d.DeviceProfile.Profiles, newName)
+	d.saveDeviceProfile()
+	return common.Success
+}
+
+// ResetDeviceProfile will reset active device profile to first-run default values.
+// Other user profiles are not modified.
+func (d *Device) ResetDeviceProfile() uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	deviceProfile := &DeviceProfile{
+		Product:     d.Product,
+		Serial:      d.Serial,
+		Path:        d.DeviceProfile.Path,
+		BootProfile: d.DeviceProfile.BootProfile,
+	}
+	d.setDefaultProfileValues(deviceProfile)
+
+	d.endPreview() // Preview belongs to current profile
+
+	// RGB reset
+	d.stopRgb() // Exit current RGB mode
+
+	// Profile is reset in place, user profiles keep pointing to active profile
+	d.mutexColor.Lock()
+	*d.DeviceProfile = *deviceProfile
+	d.mutexColor.Unlock()
+	d.saveDeviceProfile()
+	d.setDeviceColor()
+	d.setBrightnessLevel()
+	d.setControlDialListener()
+	d.resetIdleTimer() // Idle timeout belongs to the profile
+	d.checkNightMode() // Night mode schedule belongs to the profile
+	d.setSleepTimer()
+	return 1
+}
+
+// SaveUserProfile will generate a new user profile configuration and save it to a file
+func (d *Device) SaveUserProfile(profileName string) common.ResultCode {
+	if d.DeviceProfile != nil {
+		profilePath := pwd + "/database/profiles/" + d.Serial + "-" + profileName + ".json"
+
+		// Saved profile is a copy, active profile keeps its path and keyboards
+		newProfile := *d.DeviceProfile
+		newProfile.Path = profilePath
+		newProfile.Active = false
+		newProfile.Keyboards = make(map[string]*keyboards.Keyboard, len(d.DeviceProfile.Keyboards))
+		for name, keyboard := range d.DeviceProfile.Keyboards {
+			if keyboard != nil {
+				newProfile.Keyboards[name] = keyboard.Clone()
+			}
+		}
+
+		buffer, err := json.Marshal(newProfile)
+		if err != nil {
+			logger.Log(logger.Fields{"error": err}).Error("Unable to convert to json format")
+			return common.Failure
+		}
+
+		// Write JSON buffer to file
+		err = common.WriteFileAtomic(profilePath, buffer)
+		if err != nil {
+			logger.Log(logger.Fields{"error": err, "location": newProfile.Path}).Error("Unable to write device profile")
+			return common.Failure
+		}
+		d.loadDeviceProfiles()
+		return common.Success
+	}
+	return common.Failure
+}
+
+// ExportUserProfile will return current device profile in JSON format with a suggested filename
+func (d *Device) ExportUserProfile() ([]byte, string, error) {
+	if d.DeviceProfile == nil {
+		return nil, "", fmt.Errorf("device profile is not available")
+	}
+
+	// Local filesystem path has no meaning on another machine
+	profile := *d.DeviceProfile
+	profile.Path = ""
+
+	buffer, err := json.MarshalIndent(profile, "", "    ")
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to convert to json format")
+		return nil, "", err
+	}
+	return buffer, d.Serial + "-profile.json", nil
+}
+
+// ImportUserProfile will validate user profile data and save it as a new user profile for current device
+func (d *Device) ImportUserProfile(profileName string, data []byte) common.ResultCode {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", profileName); !m {
+		return common.InvalidInput
+	}
+
+	// Import never replaces existing profile, including the active one
+	profilePath := pwd + "/database/profiles/" + d.Serial + "-" + profileName + ".json"
+	if _, ok := d.UserProfiles[profileName]; ok || common.FileExists(profilePath) {
+		return common.AlreadyExists
+	}
+	if d.DeviceProfile != nil && d.DeviceProfile.Path == profilePath {
+		return common.AlreadyExists
+	}
+
+	pf := &DeviceProfile{}
+	if err := json.Unmarshal(data, pf); err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to decode imported profile")
+		return common.InvalidInput
+	}
+
+	if !d.isValidDeviceProfile(pf) {
+		logger.Log(logger.Fields{"serial": d.Serial, "profile": profileName}).Warn("Imported profile does not match device keyboard layouts")
+		return common.Incompatible
+	}
+
+	pf.Serial = d.Serial
+	pf.Product = d.Product
+	pf.Path = profilePath
+	pf.Active = false
+
+	buffer, err := json.Marshal(pf)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err}).Error("Unable to convert to json format")
+		return common.Failure
+	}
+
+	// Write JSON buffer to file
+	err = common.WriteFileAtomic(profilePath, buffer)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "location": profilePath}).Error("Unable to write device profile")
+		return common.Failure
+	}
+	d.loadDeviceProfiles()
+	return common.Success
+}
+
+// isValidDeviceProfile will check if all profile keyboards belong to device known layouts
+func (d *Device) isValidDeviceProfile(pf *DeviceProfile) bool {
+	if len(pf.Keyboards) == 0 {
+		return false
+	}
+
+	if _, ok := pf.Keyboards[pf.Profile]; !ok {
+		return false
+	}
+
+	for _, profile := range pf.Profiles {
+		if _, ok := pf.Keyboards[profile]; !ok {
+			return false
+		}
+	}
+
+	for _, keyboard := range pf.Keyboards {
+		if keyboard == nil || keyboard.Key != keyboardKey {
+			return false
+		}
+
+		if !slices.Contains(d.Layouts, keyboard.Layout) {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateBootProfile will set user profile activated on startup. Empty name keeps last active profile
+func (d *Device) UpdateBootProfile(profileName string) uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	if len(profileName) > 0 {
+		if _, ok := d.UserProfiles[profileName]; !ok {
+			return 2
+		}
+	}
+
+	d.DeviceProfile.BootProfile = profileName
+	d.saveDeviceProfile()
+	return 1
+}
+
+// scheduleColorFlush will restart RGB once per flush window, so rapid key color changes
+// produce a single device write instead of one write per key. It is called while mutexColor is held
+func (d *Device) scheduleColorFlush() {
+	if d.colorFlush != nil {
+		return // Flush is already pending and will pick up this change
+	}
+	d.colorFlush = time.AfterFunc(time.Duration(colorFlushDelay)*time.Millisecond, d.flushColor)
+}
+
+// flushColor will restart RGB with current keyboard colors
+func (d *Device) flushColor() {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+	d.colorFlush = nil
+
+	if d.DeviceProfile == nil {
+		return
+	}
+
+	d.stopRgb()        // Exit current RGB mode
+	d.setDeviceColor() // Restart RGB
+}
+
+// cancelColorFlush will cancel pending color flush, so nothing is written to a released device
+func (d *Device) cancelColorFlush() {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+	if d.colorFlush != nil {
+		d.colorFlush.Stop()
+		d.colorFlush = nil
+	}
+}
+
+// UpdateDeviceColor will update device color based on selected input
+func (d *Device) UpdateDeviceColor(keyId, keyOption int, color rgb.Color) uint8 {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]
+	if !ok {
+		return 0
+	}
+
+	// RGB loop is stopped before keyboard is changed and restarted once the change is done
+	d.stopRgb()                  // Exit current RGB mode
+	defer d.scheduleColorFlush() // Restart RGB once rapid changes are done
+
+	switch keyOption {
+	case 0:
+		{
+			for rowIndex, row := range keyboard.Row {
+				for keyIndex, key := range row.Keys {
+					if keyIndex == keyId {
+						key.Color = rgb.Color{
+							Red:        color.Red,
+							Green:      color.Green,
+							Blue:       color.Blue,
+							Brightness: 0,
+						}
+						keyboard.Row[rowIndex].Keys[keyIndex] = key
+						return 1
+					}
+				}
+			}
+		}
+	case 1:
+		{
+			rowId := -1
+			for rowIndex, row := range keyboard.Row {
+				for keyIndex := range row.Keys {
+					if keyIndex == keyId {
+						rowId = rowIndex
+						break
+					}
+				}
+			}
+
+			if rowId < 0 {
+				return 0
+			}
+
+			for keyIndex, key := range keyboard.Row[rowId].Keys {
+				key.Color = rgb.Color{
+					Red:        color.Red,
+					Green:      color.Green,
+					Blue:       color.Blue,
+					Brightness: 0,
+				}
+				keyboard.Row[rowId].Keys[keyIndex] = key
+			}
+			return 1
+		}
+	case 2:
+		{
+			keyboard.Color = color
+			return 1
+		}
+	}
+	return 0
+}
+
+// hasPerKeyColor will return true if any key in a keyboard has its own color.
+// Profiles created before per-key support only have a whole-board color.
+func (d *Device) hasPerKeyColor(keyboard *keyboards.Keyboard) bool {
+	for _, row := range keyboard.Row {
+		for _, key := range row.Keys {
+			if key.Color.Red > 0 || key.Color.Green > 0 || key.Color.Blue > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetKeyColorMap will apply colors of multiple keys, keyed by key id, in one change. Key ids are validated first,
+// so either all keys are changed or none. Profile is saved and RGB is restarted once
+func (d *Device) SetKeyColorMap(colors map[int]rgb.Color) uint8 {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	if d.DeviceProfile == nil || len(colors) == 0 {
+		return 0
+	}
+
+	keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]
+	if !ok || keyboard == nil {
+		return 0
+	}
+
+	matched := make(map[int]bool, len(colors))
+	for _, row := range keyboard.Row {
+		for keyId := range row.Keys {
+			if _, ok = colors[keyId]; ok {
+				matched[keyId] = true
+			}
+		}
+	}
+	if len(matched) != len(colors) {
+		return 2 // Unknown key id
+	}
+
+	// RGB loop is stopped before keyboard is changed and restarted once the change is done
+	d.stopRgb()
+
+	for rowIndex, row := range keyboard.Row {
+		for keyId, key := range row.Keys {
+			color, ok := colors[keyId]
+			if !ok {
+				continue
+			}
+			key.Color = rgb.Color{
+				Red:        color.Red,
+				Green:      color.Green,
+				Blue:       color.Blue,
+				Brightness: 0,
+			}
+			keyboard.Row[rowIndex].Keys[keyId] = key
+		}
+	}
+	d.saveDeviceProfile()
+	d.scheduleColorFlush()
+	return 1
+}
+
+// IdentifyDevice will flash all keys white a few times, so a device can be physically identified.
+// Active RGB mode is paused during identify and restored afterward.
+func (d *Device) IdentifyDevice() uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]
+	if !ok {
+		return 0
+	}
+
+	if !d.mutexIdentify.TryLock() {
+		return 2 // Identify is already running
+	}
+
+	go func() {
+		defer d.mutexIdentify.Unlock()
+
+		// Color changes are on hold until identify is done
+		d.mutexColor.Lock()
+		defer d.mutexColor.Unlock()
+
+		d.stopRgb() // Exit current RGB mode
+
+		dataType := dataTypeStaticColor
+		on := getStaticColorBuffer(rgb.Color{Red: 255, Green: 255, Blue: 255})
+		off := getStaticColorBuffer(rgb.Color{})
+		if perKeyColor {
+			dataType = dataTypePerKeyColor
+			on = d.getIdentifyBuffer(keyboard, 0xff)
+			off = d.getIdentifyBuffer(keyboard, 0x00)
+		}
+		for i := 0; i < identifyFlashes; i++ {
+			d.writeColor(dataType, on)
+			time.Sleep(time.Duration(identifyInterval) * time.Millisecond)
+			d.writeColor(dataType, off)
+			time.Sleep(time.Duration(identifyInterval) * time.Millisecond)
+		}
+		d.setDeviceColor() // Restore RGB
+	}()
+	return 1
+}
+
+// ReinitLeds will re-initialize LED ports and restore device RGB. It is used to recover from stuck colors
+func (d *Device) ReinitLeds() uint8 {
+	if d.DeviceProfile == nil {
+		return 0
+	}
+
+	// Color changes are on hold until LEDs are initialized. RGB loop exits between frames,
+	// so LED init is never followed by the rest of a frame started before it
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	if d.colorFlush != nil {
+		d.colorFlush.Stop() // RGB is restored below
+		d.colorFlush = nil
+	}
+	d.stopRgb() // Exit current RGB mode
+
+	err := d.initLeds()
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to initialize LED ports")
+	}
+	d.setDeviceColor()
+	d.writeBrightnessLevel()
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+// getIdentifyBuffer will return per-key color buffer with all keys set to a given value
+func (d *Device) getIdentifyBuffer(keyboard *keyboards.Keyboard, value byte) []byte {
+	var buf = make([]byte, colorPacketLength)
+	for _, row := range keyboard.Row {
+		for _, key := range row.Keys {
+			for _, packetIndex := range key.PacketIndex {
+				buf[packetIndex] = value
+				buf[packetIndex+1] = value
+				buf[packetIndex+2] = value
+			}
+		}
+	}
+	return buf
+}
+
+// UpdateDeviceColorHex will set color of all keys from #RRGGBB hex string
+func (d *Device) UpdateDeviceColorHex(hex string) uint8 {
+	color, err := rgb.HexToColor(hex)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Invalid hex color")
+		return 3
+	}
+	return d.UpdateDeviceColor(0, 2, *color)
+}
+
+// TurnOffLeds will turn off all LEDs until the next profile change. Device profile is not changed
+func (d *Device) TurnOffLeds() uint8 {
+	d.mutexColor.Lock()
+	defer d.mutexColor.Unlock()
+
+	d.stopRgb() // Exit current RGB mode
+	d.writeColorOff()
+	return 1
+}
+
+// writeColorOff will send static black color to the device
+func (d *Device) writeColorOff() {
+	d.writeColor(dataTypeStaticColor, getStaticColorBuffer(rgb.Color{}))
+}
+
+// getStaticColorBuffer will create whole-board static color packet.
+// Firmware expects color in ABGR order, the same as K100 Air Wireless
+func getStaticColorBuffer(color rgb.Color) []byte {
+	var buf = make([]byte, 93)
+	buf[3] = 0x01
+	buf[4] = 0xff
+	buf[5] = byte(color.Blue)
+	buf[6] = byte(color.Green)
+	buf[7] = byte(color.Red)
+	return buf
+}
+
+// startRgb will stop running RGB loop and return context of a new one.
+// Returned channel has to be closed by the new loop once it exits
+func (d *Device) startRgb() (context.Context, chan struct{}) {
+	d.stopRgb() // Only one RGB loop can run
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	d.mutexRgb.Lock()
+	d.rgbCancel = cancel
+	d.rgbDone = done
+	d.mutexRgb.Unlock()
+	return ctx, done
+}
+
+// stopRgb will stop RGB loop and wait until it exits, so no frame is written after it returns.
+// It doesn't block when RGB loop is not running or has already exited. Returns true when a loop was stopped
+func (d *Device) stopRgb() bool {
+	d.mutexRgb.Lock()
+	cancel, done := d.rgbCancel, d.rgbDone
+	d.rgbCancel, d.rgbDone = nil, nil
+	d.mutexRgb.Unlock()
+
+	if cancel == nil {
+		return false
 	}
-	return 0
+	cancel()
+	<-done
+	return true
 }
 
 // setDeviceColor will activate and set device RGB
@@ -903,32 +3125,173 @@ func (d *Device) setDeviceColor() {
 		return
 	}
 
+	// Color change replaces startup effect, RGB mode preview started meanwhile is kept
+	if d.cancelStartupEffect() && d.previewActive && d.DeviceProfile.RGBProfile == config.GetConfig().StartupEffect {
+		d.endPreview()
+		d.stopRgb() // Exit startup effect
+	}
+
+	if d.isNightMode() && d.DeviceProfile.RGBProfile != "off" {
+		buf := getStaticColorBuffer(nightModeColor)
+		rgb.ApplyColorOrder(buf[5:8], d.getColorOrder())
+		d.writeColor(dataTypeStaticColor, buf)
+		return
+	}
+
 	switch d.DeviceProfile.RGBProfile {
 	case "off":
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
-				var buf = make([]byte, 93)
-				buf[3] = 0x01
-				buf[4] = 0xff
-				buf[5] = 0x00
-				buf[6] = 0x00
-				buf[7] = 0x00
-				dataTypeSetColor = []byte{0x7e, 0x20, 0x01}
-				d.writeColor(buf)
+				d.writeColorOff()
 				return
 			}
 		}
 	case "keyboard":
 		{
 			if keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
-				var buf = make([]byte, 93)
-				buf[3] = 0x01
-				buf[4] = 0xff
-				buf[5] = byte(keyboard.Color.Blue)
-				buf[6] = byte(keyboard.Color.Green)
-				buf[7] = byte(keyboard.Color.Red)
-				dataTypeSetColor = []byte{0x7e, 0x20, 0x01}
-				d.writeColor(buf)
+				if perKeyColor && d.hasPerKeyColor(keyboard) {
+					// Per-key colors are sent as a static color map instead of hardware effect
+					var buf = make([]byte, colorPacketLength)
+					for _, row := range keyboard.Row {
+						for _, key := range row.Keys {
+							for _, packetIndex := range key.PacketIndex {
+								buf[packetIndex] = byte(key.Color.Red)
+								buf[packetIndex+1] = byte(key.Color.Green)
+								buf[packetIndex+2] = byte(key.Color.Blue)
+							}
+						}
+					}
+					d.writeColor(dataTypePerKeyColor, buf)
+					return
+				}
+
+				buf := getStaticColorBuffer(keyboard.Color)
+				rgb.ApplyColorOrder(buf[5:8], d.getColorOrder())
+				d.writeColor(dataTypeStaticColor, buf)
+				return
+			}
+		}
+	case "breathing":
+		{
+			if keyboard, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
+				// Breathing is not supported by firmware, color is faded in software
+				ctx, done := d.startRgb()
+				go func(keyboard *keyboards.Keyboard) {
+					defer close(done)
+					maxBrightness := 1.0
+					if d.DeviceProfile.Brightness > 0 {
+						maxBrightness = rgb.GetBrightnessValue(d.DeviceProfile.Brightness)
+					}
+
+					level := 0.0
+					rising := true
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						default:
+							color := rgb.ModifyBrightness(rgb.Color{
+								Red:        keyboard.Color.Red,
+								Green:      keyboard.Color.Green,
+								Blue:       keyboard.Color.Blue,
+								Brightness: level,
+							})
+
+							buf := getStaticColorBuffer(*color)
+							rgb.ApplyColorOrder(buf[5:8], d.getColorOrder())
+							d.writeColor(dataTypeStaticColor, buf)
+							time.Sleep(20 * time.Millisecond)
+
+							if rising {
+								level += breathingStep
+								if level >= maxBrightness {
+									level = maxBrightness
+									rising = false
+								}
+							} else {
+								level -= breathingStep
+								if level <= 0 {
+									level = 0
+									rising = true
+								}
+							}
+						}
+					}
+				}(keyboard)
+				return
+			}
+		}
+	case "cpu-temperature":
+		{
+			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
+				if d.GetRgbProfile("cpu-temperature") == nil {
+					logger.Log(logger.Fields{"profile": "cpu-temperature", "serial": d.Serial}).Warn("No such RGB profile found")
+					return
+				}
+
+				// Temperature is not supported by firmware, color is calculated in software
+				ctx, done := d.startRgb()
+				go func() {
+					defer close(done)
+					counter := 0
+					var temperatureKeys *rgb.Color
+					var written []byte // Last written color, frame is sent only when color changes
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						default:
+							// Profile is read on every frame, so speed and color changes are applied immediately
+							profile := d.GetRgbProfile("cpu-temperature")
+							if profile == nil {
+								time.Sleep(20 * time.Millisecond)
+								continue
+							}
+
+							r := rgb.New(
+								d.LEDChannels,
+								profile.Speed,
+								&profile.StartColor,
+								&profile.EndColor,
+								profile.Brightness,
+								common.Clamp(profile.Smoothness, 1, 100),
+								time.Duration(profile.Speed)*time.Second,
+								true,
+							)
+							r.MinTemp, r.MaxTemp = d.getTemperatureRange(profile)
+
+							// Brightness
+							if d.DeviceProfile.Brightness > 0 {
+								r.RGBBrightness = rgb.GetBrightnessValue(d.DeviceProfile.Brightness)
+								r.RGBStartColor.Brightness = r.RGBBrightness
+								r.RGBEndColor.Brightness = r.RGBBrightness
+							}
+
+							counter++
+							if counter >= r.Smoothness {
+								counter = 0
+							}
+
+							if temperatureKeys == nil {
+								temperatureKeys = r.RGBStartColor
+							}
+							color := r.Temperature(float64(d.CpuTemp), counter, temperatureKeys)
+							temperatureKeys = color
+
+							current := []byte{byte(color.Red), byte(color.Green), byte(color.Blue)}
+							if bytes.Equal(current, written) {
+								time.Sleep(20 * time.Millisecond)
+								continue
+							}
+							written = current
+
+							buf := getStaticColorBuffer(*color)
+							rgb.ApplyColorOrder(buf[5:8], d.getColorOrder())
+							d.writeColor(dataTypeStaticColor, buf)
+							time.Sleep(20 * time.Millisecond)
+						}
+					}
+				}()
 				return
 			}
 		}
@@ -936,8 +3299,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0x7e, 0xa0, 0x02, 0x04, 0x01}
-				d.writeColor(buf)
+				d.writeColor([]byte{0x7e, 0xa0, 0x02, 0x04, 0x01}, buf)
 				return
 			}
 		}
@@ -945,8 +3307,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0xf9, 0xb1, 0x02, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0xf9, 0xb1, 0x02, 0x04}, buf)
 				return
 			}
 		}
@@ -954,8 +3315,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0xa2, 0x09, 0x02, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0xa2, 0x09, 0x02, 0x04}, buf)
 				return
 			}
 		}
@@ -963,8 +3323,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0x87, 0xab, 0x00, 0x04, 0x06}
-				d.writeColor(buf)
+				d.writeColor([]byte{0x87, 0xab, 0x00, 0x04, 0x06}, buf)
 				return
 			}
 		}
@@ -972,8 +3331,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0x4f, 0xad, 0x02, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0x4f, 0xad, 0x02, 0x04}, buf)
 				return
 			}
 		}
@@ -981,8 +3339,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0xfa, 0xa5, 0x02, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0xfa, 0xa5, 0x02, 0x04}, buf)
 				return
 			}
 		}
@@ -990,8 +3347,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0xff, 0x7b, 0x02, 0x04, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0xff, 0x7b, 0x02, 0x04, 0x04}, buf)
 				return
 			}
 		}
@@ -999,8 +3355,7 @@ func (d *Device) setDeviceColor() {
 		{
 			if _, ok := d.DeviceProfile.Keyboards[d.DeviceProfile.Profile]; ok {
 				var buf = make([]byte, 89)
-				dataTypeSetColor = []byte{0x4c, 0xb9, 0x00, 0x04, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0x4c, 0xb9, 0x00, 0x04, 0x04}, buf)
 				return
 			}
 		}
@@ -1013,34 +3368,86 @@ func (d *Device) setDeviceColor() {
 				buf[4] = 0xff
 				buf[5] = 0xff
 				buf[6] = 0xff
-				dataTypeSetColor = []byte{0x22, 0x00, 0x03, 0x04}
-				d.writeColor(buf)
+				d.writeColor([]byte{0x22, 0x00, 0x03, 0x04}, buf)
 				return
 			}
 		}
 	}
 }
 
-// setBrightnessLevel will set global brightness level
+// setBrightnessLevel will restore global brightness level from device profile
 func (d *Device) setBrightnessLevel() {
 	if d.DeviceProfile != nil {
-		buf := make([]byte, 2)
-		binary.LittleEndian.PutUint16(buf[0:2], d.DeviceProfile.BrightnessLevel)
-		_, err := d.transfer(cmdBrightness, buf, byte(cmdKeyboard))
-		if err != nil {
-			logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to change brightness")
-		}
+		d.storeBrightnessLevel(d.DeviceProfile.BrightnessLevel)
+		d.writeBrightnessLevel()
+	}
+}
+
+// getBrightnessLevel will return current global brightness level
+func (d *Device) getBrightnessLevel() uint16 {
+	d.mutexBrightness.Lock()
+	defer d.mutexBrightness.Unlock()
+	return d.brightnessLevel
+}
+
+// storeBrightnessLevel will update current global brightness level and device profile value
+func (d *Device) storeBrightnessLevel(level uint16) {
+	d.mutexBrightness.Lock()
+	defer d.mutexBrightness.Unlock()
+
+	d.brightnessLevel = level
+	if d.DeviceProfile != nil {
+		d.DeviceProfile.BrightnessLevel = level
+	}
+}
+
+// writeBrightnessLevel will send current global brightness level to the device. Idle device and night mode stay dimmed
+func (d *Device) writeBrightnessLevel() {
+	level := d.getBrightnessLevel()
+	d.mutexIdle.Lock()
+	if d.idleDimmed && d.DeviceProfile != nil && level > d.DeviceProfile.IdleBrightness {
+		level = d.DeviceProfile.IdleBrightness
+	}
+	d.mutexIdle.Unlock()
+	d.writeBrightnessValue(d.capNightBrightness(level))
+}
+
+// writeBrightnessValue will send brightness level to the device without storing it
+func (d *Device) writeBrightnessValue(level uint16) {
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf[0:2], level)
+	_, err := d.transfer(cmdBrightness, buf, byte(cmdKeyboard))
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to change brightness")
+		return
+	}
+
+	if d.Debug {
+		logger.Log(logger.Fields{"serial": d.Serial, "level": level}).Info("writeBrightnessValue()")
 	}
 }
 
 // writeColor will write data to the device with a specific endpoint.
 // writeColor does not require endpoint closing and opening like normal Write requires.
 // Endpoint is open only once. Once the endpoint is open, color can be sent continuously.
-func (d *Device) writeColor(data []byte) {
-	buffer := make([]byte, len(dataTypeSetColor)+len(data)+headerWriteSize)
+// dataType is passed by the caller, so concurrent writers can't change each other's header.
+func (d *Device) writeColor(dataType, data []byte) {
+	if order := d.getColorOrder(); order != rgb.ColorOrderRGB && bytes.Equal(dataType, dataTypePerKeyColor) {
+		// Reordered copy, so caller buffer stays in RGB order
+		buf := make([]byte, len(data))
+		copy(buf, data)
+		rgb.ApplyColorOrder(buf, order)
+		data = buf
+	}
+
+	buffer := make([]byte, len(dataType)+len(data)+headerWriteSize)
 	binary.LittleEndian.PutUint16(buffer[0:2], uint16(len(data)))
-	copy(buffer[headerWriteSize:headerWriteSize+len(dataTypeSetColor)], dataTypeSetColor)
-	copy(buffer[headerWriteSize+len(dataTypeSetColor):], data)
+	copy(buffer[headerWriteSize:headerWriteSize+len(dataType)], dataType)
+	copy(buffer[headerWriteSize+len(dataType):], data)
+
+	if d.Simulate {
+		logger.Log(logger.Fields{"serial": d.Serial, "buffer": fmt.Sprintf("% x", buffer)}).Debug("writeColor()")
+	}
 
 	// Split packet into chunks
 	chunks := common.ProcessMultiChunkPacket(buffer, maxBufferSizePerRequest)
@@ -1061,6 +3468,34 @@ func (d *Device) writeColor(data []byte) {
 	}
 }
 
+// RawTransfer will send raw packet to a device and return device output. It is used to probe
+// undocumented commands and is rejected unless debug or simulate mode is enabled
+func (d *Device) RawTransfer(endpoint, payload []byte, command byte) ([]byte, error) {
+	if !d.Debug && !d.Simulate {
+		logger.Log(logger.Fields{"serial": d.Serial}).Warn("Raw transfer rejected. Debug mode is disabled")
+		return nil, errRawTransferDisabled
+	}
+
+	if len(endpoint) == 0 || headerSize+len(endpoint)+len(payload) > bufferSizeWrite {
+		return nil, errRawTransferLength
+	}
+
+	logger.Log(logger.Fields{
+		"serial":   d.Serial,
+		"command":  fmt.Sprintf("%02x", command),
+		"endpoint": fmt.Sprintf("% x", endpoint),
+		"payload":  fmt.Sprintf("% x", payload),
+	}).Info("RawTransfer() write")
+
+	response, err := d.transfer(endpoint, payload, command)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log(logger.Fields{"serial": d.Serial, "response": fmt.Sprintf("% x", response)}).Info("RawTransfer() read")
+	return response, nil
+}
+
 // transfer will send data to a device and retrieve device output
 func (d *Device) transfer(endpoint, buffer []byte, command byte) ([]byte, error) {
 	// Packet control, mandatory for this device
@@ -1079,111 +3514,498 @@ func (d *Device) transfer(endpoint, buffer []byte, command byte) ([]byte, error)
 	// Create read buffer
 	bufferR := make([]byte, bufferSize)
 
+	if d.Simulate {
+		logger.Log(logger.Fields{"serial": d.Serial, "buffer": fmt.Sprintf("% x", bufferW)}).Debug("transfer()")
+		return bufferR, nil
+	}
+
+	if d.unplugged {
+		return nil, errDeviceUnplugged
+	}
+
 	// Send command to a device
-	if _, err := d.dev.Write(bufferW); err != nil {
+	written, err := d.dev.Write(bufferW)
+	if err != nil {
 		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to write to a device")
+		d.recordTransferError(endpoint, err)
+		d.writeFailed(err)
 		return nil, err
 	}
+	d.writeFailures = 0
 
 	// Get data from a device
-	if _, err := d.dev.Read(bufferR); err != nil {
+	read, err := d.dev.Read(bufferR)
+	if err != nil {
 		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to read data from device")
+		d.recordTransferError(endpoint, err)
 		return nil, err
 	}
+
+	if d.Debug {
+		d.logTransferLength(endpoint, written, len(bufferW), read, len(bufferR))
+	}
 	return bufferR, nil
 }
 
+// recordTransferError will keep last transfer errors for diagnostics
+func (d *Device) recordTransferError(endpoint []byte, err error) {
+	d.mutexErrors.Lock()
+	defer d.mutexErrors.Unlock()
+
+	d.transferErrors = append(d.transferErrors, TransferError{
+		Time:     time.Now(),
+		Endpoint: fmt.Sprintf("% x", endpoint),
+		Error:    err.Error(),
+	})
+	if len(d.transferErrors) > maxTransferErrors {
+		d.transferErrors = d.transferErrors[len(d.transferErrors)-maxTransferErrors:]
+	}
+}
+
+// DumpDiagnostics will return device, profile and config state as JSON data for bug reports
+func (d *Device) DumpDiagnostics() ([]byte, error) {
+	d.mutexErrors.Lock()
+	transferErrors := slices.Clone(d.transferErrors)
+	d.mutexErrors.Unlock()
+
+	diagnostics := Diagnostics{
+		Device: DeviceDiagnostics{
+			Serial:         d.Serial,
+			Product:        d.Product,
+			VendorId:       d.VendorId,
+			ProductId:      d.ProductId,
+			Firmware:       d.Firmware,
+			DongleFirmware: d.DongleFirmware,
+			HidPath:        d.hidPath,
+			Interfaces:     d.getInterfaces(0),
+			Unplugged:      d.unplugged,
+			TransferErrors: transferErrors,
+		},
+		Config: config.GetConfig(),
+	}
+
+	if d.DeviceProfile != nil {
+		diagnostics.Profile = ProfileDiagnostics{
+			Profile:        d.DeviceProfile.Profile,
+			RGBProfile:     d.DeviceProfile.RGBProfile,
+			Brightness:     d.getBrightnessLevel(),
+			Layout:         d.DeviceProfile.Layout,
+			PhysicalLayout: d.DeviceProfile.PhysicalLayout,
+			Version:        d.DeviceProfile.Version,
+		}
+	}
+	return json.MarshalIndent(diagnostics, "", "    ")
+}
+
+// logTransferLength will log transferred byte counts and warn about short writes and reads
+func (d *Device) logTransferLength(endpoint []byte, written, writeSize, read, readSize int) {
+	fields := logger.Fields{
+		"serial":    d.Serial,
+		"endpoint":  fmt.Sprintf("% x", endpoint),
+		"written":   written,
+		"writeSize": writeSize,
+		"read":      read,
+		"readSize":  readSize,
+	}
+
+	if written != writeSize {
+		logger.Log(fields).Warn("Short write to a device")
+	}
+	if read != readSize {
+		logger.Log(fields).Warn("Short read from a device")
+	}
+	logger.Log(fields).Debug("transfer()")
+}
+
+// writeFailed will count consecutive write failures and release a device once it is unplugged.
+// It is called from transfer, while mutex is held.
+func (d *Device) writeFailed(err error) {
+	d.writeFailures++
+	if d.writeFailures < maxWriteFailures {
+		return
+	}
+	d.writeFailures = 0
+
+	if !d.isUnplugged(err) {
+		// Device is still present, communication is resumed once it wakes up
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Device is not responding. Probably asleep")
+		return
+	}
+
+	d.unplugged = true
+	go d.unplug() // Release requires mutex, so it runs once transfer is done
+}
+
+// isUnplugged will return true if a device is physically removed.
+// Sleeping device times out but keeps its HID device node, while node of unplugged device is removed.
+func (d *Device) isUnplugged(err error) bool {
+	if errors.Is(err, hid.ErrTimeout) {
+		return false
+	}
+	return !common.FileExists(d.hidPath)
+}
+
+// unplug will stop all device operations and release HID device of unplugged device
+func (d *Device) unplug() {
+	logger.Log(logger.Fields{"serial": d.Serial}).Warn("Device is unplugged. Releasing device...")
+	d.cancelStartupEffect()
+	d.stopRgb()
+	d.flushDeviceProfile()
+	d.cancelColorFlush()
+	d.stopAutoRefresh()
+	d.stopKeepAlive()
+	d.stopControlDialListener()
+	d.stopAppWatcher()
+	d.cancelIdleTimer()
+	d.stopNightMode()
+
+	mutex.Lock()
+	if d.dev != nil {
+		if err := d.dev.Close(); err != nil {
+			logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to close HID device")
+		}
+		d.dev = nil
+	}
+	mutex.Unlock()
+	common.ReleaseDeviceSerial(d.Serial)
+
+	d.mutexHandlers.Lock()
+	handlers := make([]func(serial string), len(d.unplugHandlers))
+	copy(handlers, d.unplugHandlers)
+	d.mutexHandlers.Unlock()
+
+	for _, handler := range handlers {
+		handler(d.Serial)
+	}
+}
+
+// openListener will open HID interface used by the control dial. Configured interface is used when present,
+// otherwise remaining interfaces are probed in interface order and the first readable one is used
+func (d *Device) openListener() error {
+	d.listener = nil
+	interfaceNbr := config.GetConfig().DialInterface
+
+	var candidates []*hid.DeviceInfo
+	enum := hid.EnumFunc(func(info *hid.DeviceInfo) error {
+		candidates = append(candidates, info)
+		return nil
+	})
+
+	err := hid.Enumerate(d.VendorId, d.ProductId, enum)
+	if err != nil {
+		return err
+	}
+
+	// Configured interface goes first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if (candidates[i].InterfaceNbr == interfaceNbr) != (candidates[j].InterfaceNbr == interfaceNbr) {
+			return candidates[i].InterfaceNbr == interfaceNbr
+		}
+		return candidates[i].InterfaceNbr < candidates[j].InterfaceNbr
+	})
+
+	for _, info := range candidates {
+		configured := info.InterfaceNbr == interfaceNbr
+		if !configured && info.Path == d.hidPath {
+			continue // Probe read would consume responses of the main interface
+		}
+
+		listener, e := hid.OpenPath(info.Path)
+		if e != nil {
+			logger.Log(logger.Fields{"error": e, "serial": d.Serial, "interface": info.InterfaceNbr}).Debug("Unable to open HID interface")
+			continue
+		}
+
+		if !configured && !isReadableInterface(listener) {
+			_ = listener.Close()
+			continue
+		}
+
+		d.listener = listener
+		d.listenerPath = info.Path
+		if configured {
+			logger.Log(logger.Fields{"serial": d.Serial, "interface": info.InterfaceNbr}).Info("Control dial interface selected")
+		} else {
+			logger.Log(logger.Fields{"serial": d.Serial, "interface": info.InterfaceNbr, "configured": interfaceNbr}).Warn("Configured control dial interface not found. Using probed interface")
+		}
+		return nil
+	}
+	return fmt.Errorf("control dial interface not found")
+}
+
+// isReadableInterface will return true if HID interface accepts input reads. Output-only interfaces fail on read
+func isReadableInterface(listener *hid.Device) bool {
+	buf := make([]byte, bufferSize)
+	_, err := listener.ReadWithTimeout(buf, time.Duration(listenerProbeTimeout)*time.Millisecond)
+	return err == nil || errors.Is(err, hid.ErrTimeout)
+}
+
+// reconnectListener will try to re-open control dial interface with exponential backoff
+func (d *Device) reconnectListener(exit chan bool) bool {
+	d.closeListener()
+
+	interval := listenerRetryInterval
+	for attempt := 1; attempt <= listenerMaxRetries; attempt++ {
+		select {
+		case <-exit:
+			return false
+		case <-time.After(time.Duration(interval) * time.Millisecond):
+		}
+		logger.Log(logger.Fields{"serial": d.Serial, "attempt": attempt}).Info("Reconnecting to control dial interface")
+
+		err := d.openListener()
+		if err != nil {
+			logger.Log(logger.Fields{"error": err, "serial": d.Serial, "attempt": attempt}).Warn("Unable to reconnect to control dial interface")
+			interval *= 2
+			continue
+		}
+		logger.Log(logger.Fields{"serial": d.Serial, "attempt": attempt}).Info("Control dial interface reconnected")
+		return true
+	}
+
+	logger.Log(logger.Fields{"serial": d.Serial}).Error("Giving up on control dial interface reconnect")
+	return false
+}
+
+// listenerRequired will return true when control dial interface has to be read
+func (d *Device) listenerRequired() bool {
+	if d.DeviceProfile == nil {
+		return true
+	}
+	return d.DeviceProfile.DialBindings != (ControlDialBindings{}) || d.DeviceProfile.IdleTimeout > 0
+}
+
+// setControlDialListener will start or stop control dial listener based on current profile
+func (d *Device) setControlDialListener() {
+	if !d.listenerRequired() {
+		d.stopControlDialListener()
+	} else {
+		d.controlDialListener()
+	}
+}
+
+// stopControlDialListener will stop control dial listener and release control dial interface
+func (d *Device) stopControlDialListener() {
+	d.mutexListener.Lock()
+	defer d.mutexListener.Unlock()
+
+	if d.listenerExit == nil {
+		return
+	}
+
+	d.listenerExit <- true
+	<-d.listenerDone
+	d.listenerExit = nil
+	d.listenerDone = nil
+}
+
+// closeListener will close control dial interface
+func (d *Device) closeListener() {
+	if d.listener != nil {
+		err := d.listener.Close()
+		if err != nil {
+			logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to close control dial interface")
+		}
+		d.listener = nil
+	}
+	d.listenerPath = ""
+}
+
+// syncMuteState will read mute state of default audio sink
+func (d *Device) syncMuteState() {
+	if !config.GetConfig().PactlVolume {
+		return // Mute state can be read only from sound server
+	}
+
+	muted, err := common.GetMuteState()
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to read mute state")
+		return
+	}
+	d.setMuted(muted)
+}
+
+// toggleMute will toggle mute state of default audio sink and store new state
+func (d *Device) toggleMute() {
+	inputmanager.ToggleMute(d.Serial)
+	if config.GetConfig().PactlVolume {
+		if muted, err := common.GetMuteState(); err == nil {
+			d.setMuted(muted)
+			return
+		}
+	}
+	d.setMuted(!d.isMuted()) // Mute key was handled by desktop, so state is tracked locally
+}
+
+// setMuted will store mute state
+func (d *Device) setMuted(muted bool) {
+	d.mutexMute.Lock()
+	defer d.mutexMute.Unlock()
+	d.muted = muted
+}
+
+// isMuted will return stored mute state
+func (d *Device) isMuted() bool {
+	d.mutexMute.Lock()
+	defer d.mutexMute.Unlock()
+	return d.muted
+}
+
 // controlDialListener will listen for events from the control dial
 func (d *Device) controlDialListener() {
-	pv := false
-	var brightness uint16 = 0
+	if d.Simulate {
+		return
+	}
 
-	if d.DeviceProfile.BrightnessLevel == 0 {
-		brightness = 1000
-	} else {
-		brightness = d.DeviceProfile.BrightnessLevel
+	if !d.listenerRequired() {
+		return // Control dial is disabled, interface is not opened
+	}
+
+	d.mutexListener.Lock()
+	defer d.mutexListener.Unlock()
+	if d.listenerExit != nil {
+		return // Already running
 	}
 
+	exit := make(chan bool, 1)
+	done := make(chan bool, 1)
+	d.listenerExit = exit
+	d.listenerDone = done
+
+	lastProfileSwitch := time.Time{}
+	lastBrightnessTick := time.Time{}
+	brightnessTicks := 0
+	dialPressStart := time.Time{}
+	dialLongPressDone := false
+
 	go func() {
-		buf := make([]byte, 2)
-		enum := hid.EnumFunc(func(info *hid.DeviceInfo) error {
-			if info.InterfaceNbr == 2 {
-				listener, err := hid.OpenPath(info.Path)
-				if err != nil {
-					return err
-				}
-				d.listener = listener
-			}
-			return nil
-		})
+		defer func() {
+			d.closeListener()
+			done <- true
+		}()
 
-		err := hid.Enumerate(d.VendorId, d.ProductId, enum)
+		err := d.openListener()
 		if err != nil {
-			logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Unable to enumerate devices")
+			logger.Log(logger.Fields{"error": err, "vendorId": d.VendorId, "serial": d.Serial}).Error("Unable to open control dial interface")
+			return
 		}
+		d.syncMuteState() // Dial press toggles mute, so it has to start from actual state
 
 		// Listen loop
 		data := make([]byte, bufferSize)
+		profileWarned := false
 		for {
 			// Read data from the HID device
-			_, err = d.listener.Read(data)
+			select {
+			case <-exit:
+				return
+			default:
+			}
+
+			// Read with timeout, so the listener can be stopped without closing interface under a pending read
+			_, err = d.listener.ReadWithTimeout(data, time.Duration(listenerReadTimeout)*time.Millisecond)
 			if err != nil {
+				if errors.Is(err, hid.ErrTimeout) {
+					// Long press action runs while dial is still held
+					if !dialPressStart.IsZero() && !dialLongPressDone && time.Since(dialPressStart) >= time.Duration(dialLongPressDuration)*time.Millisecond {
+						dialLongPressDone = true
+						d.dialLongPress()
+					}
+					continue
+				}
 				logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Error("Error reading data")
+				if d.reconnectListener(exit) {
+					continue
+				}
 				break
 			}
+
+			d.resetIdleTimer() // Every dial or key report is user activity
+
+			// Profile can be missing on failed load, dial input is ignored until a profile exists
+			profile := d.DeviceProfile
+			if profile == nil {
+				if !profileWarned {
+					logger.Log(logger.Fields{"serial": d.Serial}).Warn("DeviceProfile is null, control dial input is ignored")
+					profileWarned = true
+				}
+				continue
+			}
+			profileWarned = false
+
 			value := data[4]
-			switch d.DeviceProfile.ControlDial {
-			case 1:
+			click := value == 0 && data[19] == 2
+			rotate := data[1] == 5
+			bindings := profile.DialBindings
+			if bindings.LongPressAction != dialLongPressDisabled {
+				// Click is resolved on release, so it can be told apart from long press.
+				// Any report other than rotation after a click is a release
+				if click {
+					if dialPressStart.IsZero() {
+						dialPressStart = time.Now()
+						dialLongPressDone = false
+					}
+					continue
+				}
+
+				if !dialPressStart.IsZero() && !rotate {
+					held := time.Since(dialPressStart)
+					dialPressStart = time.Time{}
+					if dialLongPressDone {
+						continue
+					}
+					if held >= time.Duration(dialLongPressDuration)*time.Millisecond {
+						d.dialLongPress()
+						continue
+					}
+					click = true
+				}
+			}
+
+			// Every dial gesture runs its own action
+			if click {
+				d.runDialPressAction(bindings.ClickAction)
+				continue
+			}
+			if !rotate {
+				continue
+			}
+
+			switch bindings.RotateAction {
+			case dialRotateVolume:
 				{
-					if value == 0 && data[19] == 2 {
-						inputmanager.InputControl(inputmanager.VolumeMute, d.Serial)
-					} else {
-						if data[1] == 5 {
-							switch value {
-							case 1:
-								inputmanager.InputControl(inputmanager.VolumeUp, d.Serial)
-								break
-							case 255:
-								inputmanager.InputControl(inputmanager.VolumeDown, d.Serial)
-								break
-							}
-						}
+					if value == 1 || value == 255 {
+						inputmanager.ChangeVolume(profile.DialVolumeStep, d.isDialIncrease(value), d.Serial)
 					}
 				}
-			case 2:
+			case dialRotateBrightness:
 				{
-					if value == 0 && data[19] == 2 {
-						pv = pv != true
-						if pv {
-							brightness = 0
-						} else {
-							brightness = 1000
-						}
+					// Ticks within acceleration window are treated as a fast spin
+					if time.Since(lastBrightnessTick) <= time.Duration(dialAccelerationWindow)*time.Millisecond {
+						brightnessTicks++
 					} else {
-						if value == 1 {
-							if brightness >= 1000 {
-								brightness = 1000
-							} else {
-								brightness += 100
-							}
-						} else if value == 255 {
-							if brightness <= 0 {
-								brightness = 0
-							} else {
-								brightness -= 100
-							}
-						}
+						brightnessTicks = 1
 					}
+					lastBrightnessTick = time.Now()
 
-					if d.DeviceProfile != nil {
-						d.DeviceProfile.BrightnessLevel = brightness
-						d.saveDeviceProfile()
-
-						// Send it
-						binary.LittleEndian.PutUint16(buf[0:2], brightness)
-						_, err := d.transfer(cmdBrightness, buf, byte(cmdKeyboard))
-						if err != nil {
-							logger.Log(logger.Fields{"error": err, "serial": d.Serial}).Warn("Unable to change brightness")
+					brightness := d.getBrightnessLevel()
+					step := d.getBrightnessStep(brightnessTicks)
+					if d.isDialIncrease(value) {
+						brightness = uint16(common.Clamp(int(brightness)+step, 0, 1000))
+					} else {
+						brightness = uint16(common.Clamp(int(brightness)-step, 0, 1000))
+					}
+					d.storeBrightnessLevel(brightness)
+					d.saveDeviceProfile()
+					d.writeBrightnessLevel() // Send it
+				}
+			case dialRotateProfile:
+				{
+					if value == 1 || value == 255 {
+						// One dial step should switch only one profile
+						if time.Since(lastProfileSwitch) >= time.Duration(profileSwitchDebounce)*time.Millisecond {
+							lastProfileSwitch = time.Now()
+							d.switchKeyboardProfile(value == 1)
 						}
 					}
 				}
@@ -0,0 +1,294 @@
+package k65plusW
+
+import (
+	"OpenLinkHub/src/devices/internal/hidtest"
+	"OpenLinkHub/src/keyboards"
+	"OpenLinkHub/src/rgb"
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+)
+
+// newTestDevice will create a device with default keyboard profile and RGB profiles from repository database
+func newTestDevice(t *testing.T) (*Device, *hidtest.Device) {
+	t.Helper()
+	keyboard := &keyboards.Keyboard{}
+	hidtest.LoadJson(t, "keyboard/k65plusW.json", keyboard)
+	profiles := &rgb.RGB{}
+	hidtest.LoadJson(t, "rgb.json", profiles)
+
+	// Profiles are saved to a temporary folder
+	pwd = hidtest.WorkingDirectory(t)
+
+	fake := hidtest.New("K65 Plus Wireless")
+	d := newForTest(fake)
+	d.Serial = "TESTSERIAL"
+	d.Rgb = profiles
+	d.DeviceProfile = &DeviceProfile{
+		Serial:           d.Serial,
+		RGBProfile:       "keyboard",
+		Label:            "Keyboard",
+		Active:           true,
+		Profile:          "default",
+		Profiles:         []string{"default"},
+		Keyboards:        map[string]*keyboards.Keyboard{"default": keyboard},
+		Layout:           keyboard.Layout,
+		BrightnessLevel:  1000,
+		SleepMode:        15,
+		DialVolumeStep:   defaultVolumeStep,
+		DialAcceleration: defaultDialAcceleration,
+	}
+	d.UserProfiles = map[string]*DeviceProfile{"default": d.DeviceProfile}
+	return d, fake
+}
+
+// endpoint will match packets sent to given endpoint with given command
+func endpoint(endpoint []byte, command byte) func(packet []byte) bool {
+	return func(packet []byte) bool {
+		return packet[1] == command && bytes.Equal(packet[headerSize:headerSize+len(endpoint)], endpoint)
+	}
+}
+
+func TestTransferBufferLayout(t *testing.T) {
+	fake := hidtest.New("K65 Plus Wireless")
+	d := newForTest(fake)
+
+	if _, err := d.transfer(cmdBrightness, []byte{0xe8, 0x03}, byte(cmdKeyboard)); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	packets := fake.Packets()
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %d", len(packets))
+	}
+
+	packet := packets[0]
+	if len(packet) != bufferSizeWrite {
+		t.Fatalf("expected packet length %d, got %d", bufferSizeWrite, len(packet))
+	}
+	if packet[0] != 0x00 || packet[1] != byte(cmdKeyboard) {
+		t.Errorf("unexpected report header % x", packet[:2])
+	}
+	if !bytes.Equal(packet[headerSize:headerSize+len(cmdBrightness)], cmdBrightness) {
+		t.Errorf("unexpected endpoint % x", packet[headerSize:headerSize+len(cmdBrightness)])
+	}
+	if !bytes.Equal(packet[headerSize+len(cmdBrightness):headerSize+len(cmdBrightness)+2], []byte{0xe8, 0x03}) {
+		t.Errorf("unexpected payload % x", packet[headerSize+len(cmdBrightness):])
+	}
+}
+
+func TestWriteColorChunks(t *testing.T) {
+	fake := hidtest.New("K65 Plus Wireless")
+	d := newForTest(fake)
+
+	d.writeColor(dataTypePerKeyColor, make([]byte, colorPacketLength))
+
+	packets := fake.Packets()
+	frameLength := headerWriteSize + len(dataTypePerKeyColor) + colorPacketLength
+	expected := (frameLength + maxBufferSizePerRequest - 1) / maxBufferSizePerRequest
+	if len(packets) != expected {
+		t.Fatalf("expected %d chunks, got %d", expected, len(packets))
+	}
+
+	first := packets[0]
+	if !bytes.Equal(first[headerSize:headerSize+len(cmdWriteColor)], cmdWriteColor) {
+		t.Errorf("first chunk endpoint is % x", first[headerSize:headerSize+len(cmdWriteColor)])
+	}
+
+	header := first[headerSize+len(cmdWriteColor):]
+	if length := binary.LittleEndian.Uint16(header[0:2]); int(length) != colorPacketLength {
+		t.Errorf("expected frame length %d, got %d", colorPacketLength, length)
+	}
+	if !bytes.Equal(header[headerWriteSize:headerWriteSize+len(dataTypePerKeyColor)], dataTypePerKeyColor) {
+		t.Errorf("unexpected data type % x", header[headerWriteSize:headerWriteSize+len(dataTypePerKeyColor)])
+	}
+
+	for i, packet := range packets[1:] {
+		if packet[1] != byte(cmdKeyboard) {
+			t.Errorf("chunk %d is sent with command %02x", i+1, packet[1])
+		}
+		if !bytes.Equal(packet[headerSize:headerSize+len(dataTypeSubColor)], dataTypeSubColor) {
+			t.Errorf("chunk %d endpoint is % x", i+1, packet[headerSize:headerSize+len(dataTypeSubColor)])
+		}
+	}
+}
+
+func TestWriteColorDataType(t *testing.T) {
+	fake := hidtest.New("K65 Plus Wireless")
+	d := newForTest(fake)
+
+	d.writeColor(dataTypeStaticColor, make([]byte, 8))
+
+	packets := fake.Packets()
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %d", len(packets))
+	}
+
+	header := packets[0][headerSize+len(cmdWriteColor):]
+	if !bytes.Equal(header[headerWriteSize:headerWriteSize+len(dataTypeStaticColor)], dataTypeStaticColor) {
+		t.Errorf("unexpected data type % x", header[headerWriteSize:headerWriteSize+len(dataTypeStaticColor)])
+	}
+}
+
+func TestReadFirmware(t *testing.T) {
+	response := make([]byte, bufferSize)
+	response[3] = 1
+	response[4] = 2
+	binary.LittleEndian.PutUint16(response[5:7], 34)
+
+	fake := hidtest.New("K65 Plus Wireless", response)
+	d := newForTest(fake)
+	if err := d.getDeviceFirmware(); err != nil {
+		t.Fatalf("getDeviceFirmware failed: %v", err)
+	}
+	if d.Firmware != "1.2.34" {
+		t.Errorf("expected firmware 1.2.34, got %s", d.Firmware)
+	}
+
+	packets := fake.Packets()
+	if packets[0][1] != byte(cmdKeyboard) {
+		t.Errorf("keyboard firmware is read with command %02x", packets[0][1])
+	}
+}
+
+func TestReadFirmwareEmptyResponse(t *testing.T) {
+	fake := hidtest.New("K65 Plus Wireless")
+	d := newForTest(fake)
+
+	firmware, err := d.readFirmware(byte(cmdDongle))
+	if err != nil {
+		t.Fatalf("readFirmware failed: %v", err)
+	}
+	if firmware != firmwareUnknown {
+		t.Errorf("expected firmware %s, got %s", firmwareUnknown, firmware)
+	}
+	if len(fake.Packets()) != firmwareReadAttempts {
+		t.Errorf("expected %d firmware reads, got %d", firmwareReadAttempts, len(fake.Packets()))
+	}
+}
+
+func TestUpdateDeviceColorConcurrent(t *testing.T) {
+	d, _ := newTestDevice(t)
+	defer d.stopRgb()
+	defer d.cancelColorFlush()
+
+	var keyIds []int
+	for _, row := range d.DeviceProfile.Keyboards["default"].Row {
+		for keyId := range row.Keys {
+			keyIds = append(keyIds, keyId)
+		}
+	}
+
+	// Every writer owns a set of keys, so the last color of each key is known
+	writers, rounds := 8, 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < rounds; n++ {
+				for k := i; k < len(keyIds); k += writers {
+					color := rgb.Color{Red: float64(i * 30), Green: float64(n), Blue: 255, Brightness: 1}
+					d.UpdateDeviceColor(keyIds[k], 0, color)
+				}
+			}
+		}(i)
+	}
+
+	// Whole board color doesn't change key colors
+	boardColor := rgb.Color{Red: 10, Green: 20, Blue: 30, Brightness: 1}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < rounds; n++ {
+			d.UpdateDeviceColor(0, 2, boardColor)
+		}
+	}()
+
+	// RGB is rendered from keyboard colors while they are changed
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 50; n++ {
+			d.flushColor()
+		}
+	}()
+	wg.Wait()
+
+	owner := make(map[int]int, len(keyIds))
+	for k, keyId := range keyIds {
+		owner[keyId] = k % writers
+	}
+	for _, row := range d.DeviceProfile.Keyboards["default"].Row {
+		for keyId, key := range row.Keys {
+			red, green := float64(owner[keyId]*30), float64(rounds-1)
+			if key.Color.Red != red || key.Color.Green != green || key.Color.Blue != 255 {
+				t.Errorf("key %d color is %v, expected %.0f %.0f 255", keyId, key.Color, red, green)
+			}
+		}
+	}
+	if color := d.DeviceProfile.Keyboards["default"].Color; color != boardColor {
+		t.Errorf("board color is %v, expected %v", color, boardColor)
+	}
+}
+
+func TestStaticColorPacketRed(t *testing.T) {
+	d, fake := newTestDevice(t)
+	defer d.stopRgb()
+	d.DeviceProfile.Keyboards["default"].Color = rgb.Color{Red: 255}
+
+	d.setDeviceColor()
+
+	packets := fake.Packets()
+	if len(packets) == 0 {
+		t.Fatalf("color was not written")
+	}
+	packet := packets[0] // Color is in the first chunk
+	if !endpoint(cmdWriteColor, byte(cmdKeyboard))(packet) {
+		t.Fatalf("unexpected packet header % x", packet[:headerSize+len(cmdWriteColor)])
+	}
+
+	header := packet[headerSize+len(cmdWriteColor):]
+	if !bytes.Equal(header[headerWriteSize:headerWriteSize+len(dataTypeStaticColor)], dataTypeStaticColor) {
+		t.Fatalf("unexpected data type % x", header[headerWriteSize:headerWriteSize+len(dataTypeStaticColor)])
+	}
+
+	// Firmware expects color in ABGR order, red is the last color byte
+	color := header[headerWriteSize+len(dataTypeStaticColor):][4:8]
+	if !bytes.Equal(color, []byte{0xff, 0x00, 0x00, 0xff}) {
+		t.Errorf("expected full alpha and red in ABGR order, got % x", color)
+	}
+}
+
+func TestKeepAliveSleepState(t *testing.T) {
+	d, _ := newTestDevice(t)
+	defer d.stopRgb()
+
+	// Dongle answers first, keyboard reports sleep in response status
+	asleep := make([]byte, bufferSize)
+	asleep[2] = 0x01
+	fake := hidtest.New("K65 Plus Wireless", make([]byte, bufferSize), asleep)
+	d.dev = fake
+	keepAlive := []byte{0x12}
+
+	d.keepAlive()
+	if !d.isAsleep() {
+		t.Fatalf("keyboard sleep status was not detected")
+	}
+	if fake.Count(endpoint(keepAlive, byte(cmdDongle))) != 1 || fake.Count(endpoint(keepAlive, byte(cmdKeyboard))) != 1 {
+		t.Errorf("keepalive was not sent to both dongle and keyboard")
+	}
+
+	// Keyboard is awake again, software mode and colors are restored
+	d.keepAlive()
+	if d.isAsleep() {
+		t.Fatalf("keyboard wake up was not detected")
+	}
+	if fake.Count(endpoint(cmdSoftwareMode, byte(cmdKeyboard))) != 1 {
+		t.Errorf("software mode was not restored after wake up")
+	}
+	if fake.Count(endpoint(cmdWriteColor, byte(cmdKeyboard))) == 0 {
+		t.Errorf("colors were not restored after wake up")
+	}
+}
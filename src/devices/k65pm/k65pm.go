@@ -78,7 +78,6 @@ var (
 	timer                   = &time.Ticker{}
 	authRefreshChan         = make(chan bool)
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 128
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
@@ -86,7 +85,6 @@ var (
 	maxBufferSizePerRequest = 125
 	colorPacketLength       = 392
 	keyboardKey             = "k65pm-default"
-	defaultLayout           = "k65pm-default-US"
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
@@ -290,9 +288,9 @@ func (d *Device) initLeds() {
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -314,11 +312,12 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 	} else {
 		if d.DeviceProfile.BrightnessSlider == nil {
 			deviceProfile.BrightnessSlider = &defaultBrightness
@@ -480,8 +479,8 @@ func (d *Device) setAutoRefresh() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // UpdateDeviceLabel will set / update device label
@@ -91,7 +91,6 @@ var (
 	keepAliveChan         = make(chan bool)
 
 	mutex                   sync.Mutex
-	transferTimeout         = 500
 	bufferSize              = 64
 	bufferSizeWrite         = bufferSize + 1
 	headerSize              = 2
@@ -99,7 +98,6 @@ var (
 	maxBufferSizePerRequest = 61
 	colorPacketLength       = 371
 	keyboardKey             = "k70core-default"
-	defaultLayout           = "k70core-default-US"
 )
 
 func Init(vendorId, productId uint16, key string) *Device {
@@ -345,9 +343,9 @@ func (d *Device) initLeds() {
 	if err != nil {
 		logger.Log(logger.Fields{"error": err}).Fatal("Unable to change device mode")
 	}
-	// We need to wait around 500 ms for physical ports to re-initialize
+	// We need to wait around 500 ms for physical ports to re-initialize, delay is configurable via ledInitDelay
 	// After that we can grab any new connected / disconnected device values
-	time.Sleep(time.Duration(transferTimeout) * time.Millisecond)
+	time.Sleep(time.Duration(config.GetConfig().LedInitDelay) * time.Millisecond)
 }
 
 // saveDeviceProfile will save device profile for persistent configuration
@@ -367,11 +365,12 @@ func (d *Device) saveDeviceProfile() {
 		deviceProfile.RGBProfile = "keyboard"
 		deviceProfile.Label = "Keyboard"
 		deviceProfile.Active = true
-		keyboardMap["default"] = keyboards.GetKeyboard(defaultLayout)
+		layout := keyboards.GetDefaultLayout(keyboardKey)
+		keyboardMap["default"] = keyboards.GetKeyboard(fmt.Sprintf("%s-%s", keyboardKey, layout))
 		deviceProfile.Keyboards = keyboardMap
 		deviceProfile.Profile = "default"
 		deviceProfile.Profiles = []string{"default"}
-		deviceProfile.Layout = "US"
+		deviceProfile.Layout = layout
 		deviceProfile.ControlDial = 1
 		deviceProfile.BrightnessLevel = 1000
 	} else {
@@ -529,8 +528,8 @@ func (d *Device) setAutoRefresh() {
 
 // setCpuTemperature will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // UpdateDeviceLabel will set / update device label
@@ -1312,8 +1312,8 @@ func (d *Device) calculateTemperature(temp uint16) float64 {
 
 // setTemperatures will fetch temperature values
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 	for _, device := range d.Devices {
 		if device.HasTemps {
 			// Temperature
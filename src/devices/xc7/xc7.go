@@ -563,8 +563,8 @@ func (d *Device) getDeviceFirmware() {
 
 // setTemperatures will store current CPU temperature
 func (d *Device) setTemperatures() {
-	d.CpuTemp = temperatures.GetCpuTemperature()
-	d.GpuTemp = temperatures.GetGpuTemperature()
+	d.CpuTemp = temperatures.GetCachedCpuTemperature()
+	d.GpuTemp = temperatures.GetCachedGpuTemperature()
 }
 
 // getLiquidTemperature will fetch temperature from AIO device
@@ -7,10 +7,13 @@ package inputmanager
 // License: GPL-3.0 or later
 
 import (
+	"OpenLinkHub/src/config"
 	"OpenLinkHub/src/logger"
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"syscall"
@@ -62,6 +65,7 @@ var (
 	keyNumber11   uint16 = 0xC
 	keyNumber12   uint16 = 0xD
 	devicePath    []string
+	volumeKeyStep = 5
 )
 
 type inputEvent struct {
@@ -173,6 +177,54 @@ func InputControl(controlType uint8, serial string) {
 	closeDevice(device)
 }
 
+// ChangeVolume will change volume by a given step in percent via emulated volume keys.
+// Default audio sink is changed via pactl only when pactlVolume is enabled in config, since the service user
+// usually has no access to the user's sound server.
+func ChangeVolume(step int, increase bool, serial string) {
+	if config.GetConfig().PactlVolume {
+		sign := "-"
+		if increase {
+			sign = "+"
+		}
+
+		cmd := exec.Command("pactl", "set-sink-volume", "@DEFAULT_SINK@", fmt.Sprintf("%s%d%%", sign, step))
+		if err := cmd.Run(); err == nil {
+			return
+		}
+	}
+
+	// Volume keys change volume by a desktop defined step, usually 5 %. Step is rounded to the nearest key press
+	presses := (step + volumeKeyStep/2) / volumeKeyStep
+	if presses < 1 {
+		presses = 1
+	}
+
+	for i := 0; i < presses; i++ {
+		if increase {
+			InputControl(VolumeUp, serial)
+		} else {
+			InputControl(VolumeDown, serial)
+		}
+	}
+}
+
+// IsVolumeStepSupported will return true if volume can be changed by exactly a given step in percent.
+// Without pactl, volume keys are used and only multiples of their step can be honored
+func IsVolumeStepSupported(step int) bool {
+	return config.GetConfig().PactlVolume || step%volumeKeyStep == 0
+}
+
+// ToggleMute will toggle mute state via emulated mute key, or via pactl when pactlVolume is enabled in config
+func ToggleMute(serial string) {
+	if config.GetConfig().PactlVolume {
+		cmd := exec.Command("pactl", "set-sink-mute", "@DEFAULT_SINK@", "toggle")
+		if err := cmd.Run(); err == nil {
+			return
+		}
+	}
+	InputControl(VolumeMute, serial)
+}
+
 // emitEvent will send an event toward the device
 func emitEvent(file *os.File, event inputEvent) error {
 	var buf bytes.Buffer
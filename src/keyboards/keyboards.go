@@ -8,22 +8,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 )
 
 var (
-	pwd       = ""
-	location  = ""
-	keyboards = map[string]Keyboard{}
+	pwd            = ""
+	location       = ""
+	keyboards      = map[string]Keyboard{}
+	fallbackLayout = "US"
+	// Territories where ANSI (US) keyboard layout is common, all others are using ISO (EU) layout
+	ansiTerritories = []string{"US", "CA", "AU", "NZ", "PH", "IN", "SG", "MY", "CN", "TW", "HK", "KR"}
+)
+
+// keyUnitSize is a width of a standard key including its margin
+const keyUnitSize = 85.0
+
+// Physical layouts. ISO and ANSI keyboards have a different key set (enter, backslash, left shift) and packet indexes
+const (
+	PhysicalLayoutANSI = "ANSI"
+	PhysicalLayoutISO  = "ISO"
 )
 
 type Keyboard struct {
-	Key    string        `json:"key"`
-	Device string        `json:"device"`
-	Layout string        `json:"layout"`
-	Rows   int           `json:"rows"`
-	Row    map[int]Row   `json:"row"`
-	Zones  map[int]Zones `json:"zones"`
-	Color  rgb.Color     `json:"color"`
+	Key            string        `json:"key"`
+	Device         string        `json:"device"`
+	Layout         string        `json:"layout"`
+	PhysicalLayout string        `json:"physicalLayout"`
+	Rows           int           `json:"rows"`
+	Row            map[int]Row   `json:"row"`
+	Zones          map[int]Zones `json:"zones"`
+	Color          rgb.Color     `json:"color"`
+}
+
+// KeyPosition struct contains key center position in key units
+type KeyPosition struct {
+	X float64
+	Y float64
 }
 
 type Zones struct {
@@ -31,7 +52,16 @@ type Zones struct {
 }
 
 type Row struct {
-	Keys map[int]Key `json:"keys"`
+	Keys       map[int]Key `json:"keys"`
+	Brightness uint8       `json:"brightness,omitempty"` // Row brightness in percent, 0 means no override
+}
+
+// GetBrightnessFactor will return row brightness multiplier in range of 0 - 1
+func (r Row) GetBrightnessFactor() float64 {
+	if r.Brightness == 0 || r.Brightness > 100 {
+		return 1
+	}
+	return float64(r.Brightness) / 100
 }
 
 type Key struct {
@@ -46,6 +76,39 @@ type Key struct {
 	Svg         bool      `json:"svg"`
 }
 
+// GetBrightnessFactor will return key brightness multiplier in range of 0 - 1. Unset brightness keeps key at full brightness
+func (k Key) GetBrightnessFactor() float64 {
+	if k.Color.Brightness <= 0 || k.Color.Brightness > 1 {
+		return 1
+	}
+	return k.Color.Brightness
+}
+
+// Geometry struct contains keyboard layout geometry without colors
+type Geometry struct {
+	Key            string        `json:"key"`
+	Layout         string        `json:"layout"`
+	PhysicalLayout string        `json:"physicalLayout"`
+	LEDChannels    int           `json:"ledChannels"`
+	Rows           int           `json:"rows"`
+	Row            []RowGeometry `json:"row"`
+}
+
+type RowGeometry struct {
+	RowId int           `json:"rowId"`
+	Keys  []KeyGeometry `json:"keys"`
+}
+
+type KeyGeometry struct {
+	KeyId       int    `json:"keyId"`
+	KeyName     string `json:"keyName"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Left        int    `json:"left"`
+	Top         int    `json:"top"`
+	PacketIndex []int  `json:"packetIndex"`
+}
+
 // Init will load and initialize keyboard data
 func Init() {
 	pwd = config.GetConfig().ConfigPath
@@ -89,6 +152,10 @@ func Init() {
 			continue
 		}
 
+		if len(keyboard.PhysicalLayout) < 1 {
+			keyboard.PhysicalLayout = getPhysicalLayout(keyboard.Layout)
+		}
+
 		key := fmt.Sprintf("%s-%s", keyboard.Key, keyboard.Layout)
 		keyboards[key] = keyboard
 		err = file.Close()
@@ -106,6 +173,126 @@ func GetKeyboard(key string) *Keyboard {
 	return nil
 }
 
+// Clone will return a deep copy of a keyboard. Rows, keys and zones are copied, so
+// changes of a clone are not reflected in the original keyboard. Clone of nil keyboard is nil
+func (k *Keyboard) Clone() *Keyboard {
+	if k == nil {
+		return nil
+	}
+	clone := *k
+
+	if k.Row != nil {
+		clone.Row = make(map[int]Row, len(k.Row))
+		for rowId, row := range k.Row {
+			keys := make(map[int]Key, len(row.Keys))
+			for keyId, key := range row.Keys {
+				if key.PacketIndex != nil {
+					key.PacketIndex = append([]int(nil), key.PacketIndex...)
+				}
+				keys[keyId] = key
+			}
+			row.Keys = keys
+			clone.Row[rowId] = row
+		}
+	}
+
+	if k.Zones != nil {
+		clone.Zones = make(map[int]Zones, len(k.Zones))
+		for zoneId, zone := range k.Zones {
+			clone.Zones[zoneId] = zone
+		}
+	}
+	return &clone
+}
+
+// GetKeyPositions will return center position of every key in key units, keyed by key id.
+// Key left value is a margin from the previous key in a row, so positions are accumulated per row
+func (k *Keyboard) GetKeyPositions() map[int]KeyPosition {
+	positions := make(map[int]KeyPosition)
+	for rowId, row := range k.Row {
+		keyIds := make([]int, 0, len(row.Keys))
+		for keyId := range row.Keys {
+			keyIds = append(keyIds, keyId)
+		}
+		slices.Sort(keyIds)
+
+		x := 0
+		for _, keyId := range keyIds {
+			key := row.Keys[keyId]
+			x += key.Left
+			positions[keyId] = KeyPosition{
+				X: (float64(x) + float64(key.Width)/2) / keyUnitSize,
+				Y: float64(rowId),
+			}
+			x += key.Width
+		}
+	}
+	return positions
+}
+
+// GetPhysicalLayout will return physical layout (ANSI or ISO) for a given keyboard type
+func GetPhysicalLayout(key string) string {
+	if keyboard, ok := keyboards[key]; ok {
+		return keyboard.PhysicalLayout
+	}
+	return ""
+}
+
+// getPhysicalLayout will return physical layout for keyboard files without physicalLayout field. Only US layout is ANSI
+func getPhysicalLayout(layout string) string {
+	if layout == fallbackLayout {
+		return PhysicalLayoutANSI
+	}
+	return PhysicalLayoutISO
+}
+
+// GetGeometry will return layout geometry for a given keyboard type. Rows and keys are sorted by their id
+func GetGeometry(key string, ledChannels int) *Geometry {
+	keyboard, ok := keyboards[key]
+	if !ok {
+		return nil
+	}
+
+	geometry := &Geometry{
+		Key:            keyboard.Key,
+		Layout:         keyboard.Layout,
+		PhysicalLayout: keyboard.PhysicalLayout,
+		LEDChannels:    ledChannels,
+		Rows:           keyboard.Rows,
+	}
+
+	rowIds := make([]int, 0, len(keyboard.Row))
+	for rowId := range keyboard.Row {
+		rowIds = append(rowIds, rowId)
+	}
+	slices.Sort(rowIds)
+
+	for _, rowId := range rowIds {
+		row := keyboard.Row[rowId]
+		keyIds := make([]int, 0, len(row.Keys))
+		for keyId := range row.Keys {
+			keyIds = append(keyIds, keyId)
+		}
+		slices.Sort(keyIds)
+
+		rowGeometry := RowGeometry{RowId: rowId}
+		for _, keyId := range keyIds {
+			key := row.Keys[keyId]
+			rowGeometry.Keys = append(rowGeometry.Keys, KeyGeometry{
+				KeyId:       keyId,
+				KeyName:     key.KeyName,
+				Width:       key.Width,
+				Height:      key.Height,
+				Left:        key.Left,
+				Top:         key.Top,
+				PacketIndex: key.PacketIndex,
+			})
+		}
+		geometry.Row = append(geometry.Row, rowGeometry)
+	}
+	return geometry
+}
+
 // GetLayouts will return a list of available layouts for given keyboard
 func GetLayouts(key string) []string {
 	var layouts []string
@@ -116,3 +303,43 @@ func GetLayouts(key string) []string {
 	}
 	return layouts
 }
+
+// GetDefaultLayout will return keyboard layout used for new device profiles.
+// Layout is taken from configuration or detected from host locale. US is used when layout is not available
+func GetDefaultLayout(key string) string {
+	layout := config.GetConfig().KeyboardLayout
+	if len(layout) == 0 {
+		layout = getLocaleLayout()
+	}
+
+	if slices.Contains(GetLayouts(key), layout) {
+		return layout
+	}
+	return fallbackLayout
+}
+
+// getLocaleLayout will map host locale territory to keyboard layout
+func getLocaleLayout() string {
+	locale := ""
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(env); len(value) > 0 {
+			locale = value
+			break
+		}
+	}
+
+	// Locale format is language_TERRITORY.codeset@modifier, e.g. de_DE.UTF-8
+	if index := strings.IndexAny(locale, ".@"); index >= 0 {
+		locale = locale[:index]
+	}
+
+	parts := strings.Split(locale, "_")
+	if len(parts) < 2 {
+		return fallbackLayout
+	}
+
+	if slices.Contains(ansiTerritories, strings.ToUpper(parts[1])) {
+		return "US"
+	}
+	return "EU"
+}
@@ -12,6 +12,9 @@ type Fields = log.Fields
 func Init() {
 	logFilename := config.GetConfig().ConfigPath + "/stdout.log"
 	log.SetFormatter(&log.JSONFormatter{})
+	if config.GetConfig().Debug || config.GetConfig().Simulate {
+		log.SetLevel(log.DebugLevel)
+	}
 	file, err := os.OpenFile(logFilename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err == nil {
 		log.SetOutput(file)
@@ -89,3 +89,28 @@ func (r *ActiveRGB) Rainbow(startTime time.Time) {
 		r.Output = SetColor(buf)
 	}
 }
+
+// RainbowWave will run rainbow RGB function with a moving wave on top of it.
+// Wave phase of a channel is taken from distance map, nil map uses channel order
+func (r *ActiveRGB) RainbowWave(startTime time.Time, wavePosition float64, distance map[int]float64) {
+	elapsed := time.Since(startTime).Seconds() * r.RgbModeSpeed
+	buf := map[int][]byte{}
+	colors := generateRainbowColors(r.LightChannels, elapsed, r.RGBBrightness)
+	for i, color := range colors {
+		position := float64(i)
+		if distance != nil {
+			position = -distance[i]
+		}
+		intensity := 0.5 * (1 + math.Sin(2*math.Pi*(wavePosition+position)/r.RgbModeSpeed))
+		buf[i] = []byte{
+			byte(color.R * intensity),
+			byte(color.G * intensity),
+			byte(color.B * intensity),
+		}
+	}
+	if r.Inverted {
+		r.Output = SetColorInverted(buf)
+	} else {
+		r.Output = SetColor(buf)
+	}
+}
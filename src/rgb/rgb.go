@@ -3,10 +3,12 @@ package rgb
 import (
 	"OpenLinkHub/src/common"
 	"encoding/json"
+	"fmt"
 	"math"
 	"math/rand"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 )
 
@@ -37,6 +39,8 @@ type Profile struct {
 	EndColor    Color   `json:"end"`
 	MinTemp     float64 `json:"minTemp"`
 	MaxTemp     float64 `json:"maxTemp"`
+	Hold        int     `json:"hold,omitempty"`      // Milliseconds sweep modes hold end color before reversing
+	Intensity   float64 `json:"intensity,omitempty"` // Color saturation of generated effects, 0-1. Zero uses effect default
 }
 
 type ActiveRGB struct {
@@ -59,6 +63,7 @@ type ActiveRGB struct {
 	MinTemp                float64
 	MaxTemp                float64
 	Inverted               bool
+	Intensity              float64
 }
 
 var (
@@ -71,6 +76,8 @@ var (
 		MiddleColor: Color{Red: 0, Green: 0, Blue: 0, Brightness: 0},
 		EndColor:    Color{Red: 0, Green: 0, Blue: 0, Brightness: 0},
 	}
+	minRandomLuminance  = 60.0 // Random colors below this luminance look like LEDs are off
+	maxRandomColorTries = 10
 )
 
 // GetRGB will return RGB
@@ -256,17 +263,44 @@ func toRGB(c HSL) *Color {
 
 // GenerateRandomColor will generate random color with provided bts as brightness
 func GenerateRandomColor(bts float64) *Color {
-	r := rand.Intn(256) // Random value between 0 and 255
-	g := rand.Intn(256) // Random value between 0 and 255
-	b := rand.Intn(256) // Random value between 0 and 255
+	color := &Color{Brightness: bts}
+	for i := 0; i < maxRandomColorTries; i++ {
+		color.Red = float64(rand.Intn(256))   // Random value between 0 and 255
+		color.Green = float64(rand.Intn(256)) // Random value between 0 and 255
+		color.Blue = float64(rand.Intn(256))  // Random value between 0 and 255
+
+		// Near-black colors are regenerated, so effects always start visibly
+		if getLuminance(color) >= minRandomLuminance {
+			break
+		}
+	}
+	return ModifyBrightness(*color)
+}
+
+// getLuminance will return relative luminance of a color in range of 0 - 255
+func getLuminance(c *Color) float64 {
+	return 0.2126*c.Red + 0.7152*c.Green + 0.0722*c.Blue
+}
+
+// HexToColor will convert #RRGGBB hex string to Color with full brightness
+func HexToColor(hex string) (*Color, error) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return nil, fmt.Errorf("invalid hex color %q, expected #RRGGBB", hex)
+	}
+
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex color %q: %w", hex, err)
+	}
 
 	color := &Color{
-		Red:        float64(r),
-		Green:      float64(g),
-		Blue:       float64(b),
-		Brightness: bts,
+		Red:        float64((value >> 16) & 0xff),
+		Green:      float64((value >> 8) & 0xff),
+		Blue:       float64(value & 0xff),
+		Brightness: 1,
+		Hex:        hex,
 	}
-	return ModifyBrightness(*color)
+	return color, nil
 }
 
 // ModifyBrightness will modify color brightness
@@ -282,6 +316,30 @@ func ModifyBrightness(c Color) *Color {
 	return toRGB(hsl)
 }
 
+// Color byte orders of LED channel data. Order is relative to native order of a device
+const (
+	ColorOrderRGB = "RGB" // Native order
+	ColorOrderBGR = "BGR" // Red and blue are swapped
+	ColorOrderGRB = "GRB" // Red and green are swapped
+)
+
+// IsValidColorOrder will return true if color order is supported
+func IsValidColorOrder(order string) bool {
+	return order == ColorOrderRGB || order == ColorOrderBGR || order == ColorOrderGRB
+}
+
+// ApplyColorOrder will rearrange every 3 byte color of a buffer into a given color order
+func ApplyColorOrder(buf []byte, order string) {
+	for i := 0; i+2 < len(buf); i += 3 {
+		switch order {
+		case ColorOrderBGR:
+			buf[i], buf[i+2] = buf[i+2], buf[i]
+		case ColorOrderGRB:
+			buf[i], buf[i+1] = buf[i+1], buf[i]
+		}
+	}
+}
+
 // SetColor will generate byte output for RGB data
 func SetColor(data map[int][]byte) []byte {
 	buffer := make([]byte, len(data)*3)
@@ -5,14 +5,15 @@ import (
 	"time"
 )
 
+var watercolorSaturation = 0.4 // Lower saturation for watercolor effect
+
 // watercolorColor function returns an RGB color corresponding to a given position in the watercolor spectrum
-func watercolorColor(position float64) (int, int, int) {
+func watercolorColor(position, saturation float64) (int, int, int) {
 	// Normalize position to be between 0 and 1
 	position = math.Mod(position, 1.0)
 
 	// Adjust hue, saturation, and brightness to create pastel colors
 	hue := position * 360 // Convert position to hue angle (0-360 degrees)
-	saturation := 0.4     // Lower saturation for watercolor effect
 	bts := 1.0            // Full brightness for watercolor effect
 
 	return HSBToRGB(hue, saturation, bts)
@@ -49,12 +50,12 @@ func HSBToRGB(h, s, v float64) (int, int, int) {
 }
 
 // generateWaterColors will generate color based on start and end color
-func generateWaterColors(lightChannels int, elapsedTime, brightnessValue float64) []struct{ R, G, B float64 } {
+func generateWaterColors(lightChannels int, elapsedTime, brightnessValue, saturation float64) []struct{ R, G, B float64 } {
 	colors := make([]struct{ R, G, B float64 }, lightChannels)
 	for i := 0; i < lightChannels; i++ {
 		position := (float64(i) / float64(lightChannels)) + (elapsedTime / 4.0)
 		position = math.Mod(position, 1.0) // Keep position within the 0-1 range
-		r, g, b := watercolorColor(position)
+		r, g, b := watercolorColor(position, saturation)
 
 		color := &Color{
 			Red:        float64(r),
@@ -72,7 +73,11 @@ func generateWaterColors(lightChannels int, elapsedTime, brightnessValue float64
 func (r *ActiveRGB) Watercolor(startTime time.Time) {
 	elapsed := time.Since(startTime).Seconds() * r.RgbModeSpeed
 	buf := map[int][]byte{}
-	colors := generateWaterColors(r.LightChannels, elapsed, r.RGBBrightness)
+	saturation := watercolorSaturation
+	if r.Intensity > 0 {
+		saturation = math.Min(r.Intensity, 1)
+	}
+	colors := generateWaterColors(r.LightChannels, elapsed, r.RGBBrightness, saturation)
 	for i, color := range colors {
 		buf[i] = []byte{
 			byte(color.R),
@@ -0,0 +1,40 @@
+package rgb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatercolorFrames(t *testing.T) {
+	// Four channels are a quarter of the hue circle apart, every second moves colors by one channel
+	frames := map[float64][]struct{ R, G, B float64 }{
+		0: {{255, 153, 153}, {204, 255, 153}, {153, 255, 255}, {204, 153, 255}},
+		1: {{204, 255, 153}, {153, 255, 255}, {204, 153, 255}, {255, 153, 153}},
+		2: {{153, 255, 255}, {204, 153, 255}, {255, 153, 153}, {204, 255, 153}},
+	}
+
+	for elapsed, expected := range frames {
+		colors := generateWaterColors(len(expected), elapsed, 1, watercolorSaturation)
+		for i, color := range colors {
+			if color != expected[i] {
+				t.Errorf("frame %.0f channel %d is %v, expected %v", elapsed, i, color, expected[i])
+			}
+		}
+	}
+}
+
+func TestWatercolorOutputIsPastel(t *testing.T) {
+	r := New(123, 1, nil, nil, 1, 1, time.Second, false)
+	r.Watercolor(time.Now())
+
+	if len(r.Output) != 123*3 {
+		t.Fatalf("expected %d bytes, got %d", 123*3, len(r.Output))
+	}
+
+	// Saturation of 0.4 keeps every channel at about 60 % of full brightness or more
+	for i, value := range r.Output {
+		if value < 150 {
+			t.Fatalf("byte %d is %d, watercolor should stay pastel", i, value)
+		}
+	}
+}
@@ -27,3 +27,25 @@ func (r *ActiveRGB) Wave(wavePosition float64) {
 		r.Output = SetColor(buf)
 	}
 }
+
+// WaveDistance will run wave RGB function travelling away from wave origin.
+// Distance of every channel from the origin defines its phase, channels without distance are treated as origin
+func (r *ActiveRGB) WaveDistance(wavePosition float64, distance map[int]float64) {
+	buf := map[int][]byte{}
+	color := r.RGBStartColor
+	modify := ModifyBrightness(*color)
+
+	for i := 0; i < r.LightChannels; i++ {
+		wavePos := (wavePosition - distance[i]) / r.RgbModeSpeed
+		intensity := 0.5 * (1 + math.Sin(2*math.Pi*wavePos))
+		red := modify.Red * intensity
+		green := modify.Green * intensity
+		blue := modify.Blue * intensity
+		buf[i] = []byte{byte(red), byte(green), byte(blue)}
+	}
+	if r.Inverted {
+		r.Output = SetColorInverted(buf)
+	} else {
+		r.Output = SetColor(buf)
+	}
+}
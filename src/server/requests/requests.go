@@ -1,6 +1,7 @@
 package requests
 
 import (
+	"OpenLinkHub/src/common"
 	"OpenLinkHub/src/config"
 	"OpenLinkHub/src/dashboard"
 	"OpenLinkHub/src/devices"
@@ -8,10 +9,12 @@ import (
 	"OpenLinkHub/src/rgb"
 	"OpenLinkHub/src/scheduler"
 	"OpenLinkHub/src/temperatures"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 )
 
 // Payload contains data from a client about device speed change
@@ -22,23 +25,33 @@ type Payload struct {
 	Rotation            uint8             `json:"rotation"`
 	Value               uint16            `json:"value"`
 	Color               rgb.Color         `json:"color"`
+	StartColor          rgb.Color         `json:"startColor"`
+	EndColor            rgb.Color         `json:"endColor"`
+	ColorHex            string            `json:"colorHex"`
 	Profile             string            `json:"profile"`
 	Label               string            `json:"label"`
 	Static              bool              `json:"static"`
 	Sensor              uint8             `json:"sensor"`
 	ZeroRpm             bool              `json:"zeroRpm"`
 	HwmonDeviceId       string            `json:"hwmonDeviceId"`
+	GpuSensor           string            `json:"gpuSensor"`
 	Enabled             bool              `json:"enabled"`
 	DeviceType          int               `json:"deviceType"`
 	KeyOption           int               `json:"keyOption"`
 	AreaOption          int               `json:"areaOption"`
 	KeyId               int               `json:"keyId"`
 	AreaId              int               `json:"areaId"`
+	RowId               int               `json:"rowId"`
 	DeviceAmount        int               `json:"deviceAmount"`
 	PortId              int               `json:"portId"`
 	UserProfileName     string            `json:"userProfileName"`
+	Description         string            `json:"description"`
+	Tags                []string          `json:"tags"`
 	LcdSerial           string            `json:"lcdSerial"`
 	KeyboardProfileName string            `json:"keyboardProfileName"`
+	NewProfileName      string            `json:"newProfileName"`
+	AppBindings         map[string]string `json:"appBindings"`
+	FallbackProfile     string            `json:"fallbackProfile"`
 	KeyboardLayout      string            `json:"keyboardLayout"`
 	KeyboardControlDial int               `json:"keyboardControlDial"`
 	SleepMode           int               `json:"sleepMode"`
@@ -55,10 +68,35 @@ type Payload struct {
 	Stages              map[int]uint16    `json:"stages"`
 	ColorDpi            rgb.Color         `json:"colorDpi"`
 	ColorZones          map[int]rgb.Color `json:"colorZones"`
+	KeyColors           map[int]rgb.Color `json:"keyColors"`
 	Image               string            `json:"image"`
+	ProfileData         string            `json:"profileData"`
+	VolumeStep          int               `json:"volumeStep"`
+	DialAcceleration    int               `json:"dialAcceleration"`
+	DialInvert          bool              `json:"dialInvert"`
+	LongPressAction     int               `json:"longPressAction"`
+	RotateAction        int               `json:"rotateAction"`
+	ClickAction         int               `json:"clickAction"`
+	FrameDelay          int               `json:"frameDelay"`
+	MinTemp             float64           `json:"minTemp"`
+	MaxTemp             float64           `json:"maxTemp"`
+	Intensity           float64           `json:"intensity"`
+	ColorOrder          string            `json:"colorOrder"`
+	IdleTimeout         int               `json:"idleTimeout"`
+	IdleBrightness      uint16            `json:"idleBrightness"`
+	MirrorTarget        string            `json:"mirrorTarget"`
+	NightStart          string            `json:"nightStart"`
+	NightEnd            string            `json:"nightEnd"`
+	BrightnessLevel     uint16            `json:"brightnessLevel"`
+	PollInterval        int               `json:"pollInterval"`
+	AnimatedKeys        []int             `json:"animatedKeys"`
+	Endpoint            string            `json:"endpoint"`
+	Buffer              string            `json:"buffer"`
+	Command             uint8             `json:"command"`
 	Status              int
 	Code                int
 	Message             string
+	Data                string
 }
 
 func ProcessDeleteTemperatureProfile(r *http.Request) *Payload {
@@ -158,6 +196,30 @@ func ProcessUpdateTemperatureProfile(r *http.Request) *Payload {
 	}
 }
 
+// ProcessTemperaturePollInterval will process POST request from a client for temperature polling interval change
+func ProcessTemperaturePollInterval(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.PollInterval < 250 || req.PollInterval > 60000 {
+		return &Payload{Message: "Polling interval must be between 250 and 60000 ms", Code: http.StatusOK, Status: 0}
+	}
+
+	if err = config.SetTemperaturePollInterval(req.PollInterval); err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to save configuration")
+		return &Payload{Message: "Unable to change polling interval", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Polling interval successfully changed", Code: http.StatusOK, Status: 1}
+}
+
 func ProcessNewTemperatureProfile(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
@@ -525,8 +587,8 @@ func ProcessSaveUserProfile(r *http.Request) *Payload {
 	return &Payload{Message: "Unable to save user profile", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessSaveDeviceProfile will process PUT request from a client for device profile save
-func ProcessSaveDeviceProfile(r *http.Request) *Payload {
+// ProcessImportUserProfile will process PUT request from a client for user profile import
+func ProcessImportUserProfile(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -538,19 +600,15 @@ func ProcessSaveDeviceProfile(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.KeyboardProfileName) < 0 {
-		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	if len(req.ProfileData) < 1 {
+		return &Payload{Message: "Profile file is empty", Code: http.StatusOK, Status: 0}
 	}
 
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.UserProfileName); !m {
 		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
 	}
 
@@ -559,18 +617,22 @@ func ProcessSaveDeviceProfile(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.SaveDeviceProfile(req.DeviceId, req.KeyboardProfileName, req.New)
-	switch status {
-	case 1:
-		return &Payload{Message: "Keyboard profile successfully saved", Code: http.StatusOK, Status: 1}
-	case 2:
-		return &Payload{Message: "Unable to save keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
-	}
-	return &Payload{Message: "Unable to save keyboard profile", Code: http.StatusOK, Status: 0}
+	status := devices.ImportUserProfile(req.DeviceId, req.UserProfileName, []byte(req.ProfileData))
+	switch common.ResultCode(status) {
+	case common.Success:
+		return &Payload{Message: "User profile successfully imported", Code: http.StatusOK, Status: 1}
+	case common.InvalidInput:
+		return &Payload{Message: "Unable to read profile file. File is not a valid profile", Code: http.StatusOK, Status: 0}
+	case common.Incompatible:
+		return &Payload{Message: "Profile does not match keyboard layouts supported by this device", Code: http.StatusOK, Status: 0}
+	case common.AlreadyExists:
+		return &Payload{Message: "User profile with this name already exists", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to import user profile", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeKeyboardLayout will process POST request from a client for device layout change
-func ProcessChangeKeyboardLayout(r *http.Request) *Payload {
+// ProcessChangeRgbFrameDelay will process POST request from a client for RGB frame delay change
+func ProcessChangeRgbFrameDelay(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -582,20 +644,12 @@ func ProcessChangeKeyboardLayout(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.KeyboardLayout) < 1 {
-		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardLayout); !m {
-		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	if req.FrameDelay < 10 || req.FrameDelay > 200 {
+		return &Payload{Message: "Frame delay must be between 10 and 200 ms", Code: http.StatusOK, Status: 0}
 	}
 
 	if devices.GetDevice(req.DeviceId) == nil {
@@ -603,18 +657,18 @@ func ProcessChangeKeyboardLayout(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.ChangeKeyboardLayout(req.DeviceId, req.KeyboardLayout)
+	status := devices.ChangeRgbFrameDelay(req.DeviceId, req.FrameDelay)
 	switch status {
 	case 1:
-		return &Payload{Message: "Keyboard layout successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "RGB frame delay successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change keyboard layout. Please try again", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Frame delay must be between 10 and 200 ms", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change keyboard layout", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change RGB frame delay", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeControlDial will process POST request from a client for device control dial change
-func ProcessChangeControlDial(r *http.Request) *Payload {
+// ProcessChangeTemperatureRange will process POST request from a client for device temperature range change
+func ProcessChangeTemperatureRange(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -626,14 +680,6 @@ func ProcessChangeControlDial(r *http.Request) *Payload {
 		}
 	}
 
-	if req.KeyboardControlDial < 1 {
-		return &Payload{Message: "Invalid control dial option", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
@@ -643,18 +689,18 @@ func ProcessChangeControlDial(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.ChangeKeyboardControlDial(req.DeviceId, req.KeyboardControlDial)
+	status := devices.ChangeTemperatureRange(req.DeviceId, req.MinTemp, req.MaxTemp)
 	switch status {
 	case 1:
-		return &Payload{Message: "Keyboard control dial successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Temperature range successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change keyboard control dial. Please try again", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Minimum temperature must be lower than maximum temperature", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change keyboard control dial", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change temperature range", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeSleepMode will process POST request from a client for device sleep change
-func ProcessChangeSleepMode(r *http.Request) *Payload {
+// ProcessChangeRgbIntensity will process POST request from a client for RGB effect intensity change
+func ProcessChangeRgbIntensity(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -666,14 +712,6 @@ func ProcessChangeSleepMode(r *http.Request) *Payload {
 		}
 	}
 
-	if req.SleepMode < 1 || req.SleepMode > 60 {
-		return &Payload{Message: "Invalid sleep option", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
@@ -682,19 +720,23 @@ func ProcessChangeSleepMode(r *http.Request) *Payload {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.Profile); !m {
+		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
+	}
+
 	// Run it
-	status := devices.ChangeDeviceSleepMode(req.DeviceId, req.SleepMode)
+	status := devices.ChangeRgbIntensity(req.DeviceId, req.Profile, req.Intensity)
 	switch status {
 	case 1:
-		return &Payload{Message: "Device sleep mode successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "RGB intensity successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change device sleep mode. Please try again", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change device sleep mode", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change RGB intensity", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessDeleteKeyboardProfile will process DELETE request from a client for device profile deletion
-func ProcessDeleteKeyboardProfile(r *http.Request) *Payload {
+// ProcessChangeIdleDimming will process POST request from a client for device idle dimming change
+func ProcessChangeIdleDimming(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -706,41 +748,27 @@ func ProcessDeleteKeyboardProfile(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.KeyboardProfileName) < 0 {
-		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
-		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
-	}
-
 	if devices.GetDevice(req.DeviceId) == nil {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	// Run it
-	status := devices.DeleteKeyboardProfile(req.DeviceId, req.KeyboardProfileName)
+	status := devices.ChangeIdleDimming(req.DeviceId, req.IdleTimeout, req.IdleBrightness)
 	switch status {
 	case 1:
-		return &Payload{Message: "Keyboard profile successfully deleted", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Idle dimming successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to delete keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
-	case 3:
-		return &Payload{Message: "Default keyboard profile can not be deleted", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Idle timeout must be between 0 and 3600 seconds and brightness between 0 and 1000", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to save keyboard profile", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change idle dimming", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeKeyboardProfile will process POST request from a client for keyboard profile change
-func ProcessChangeKeyboardProfile(r *http.Request) *Payload {
+// ProcessChangeNightMode will process POST request from a client for device night mode schedule change
+func ProcessChangeNightMode(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -752,39 +780,29 @@ func ProcessChangeKeyboardProfile(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.KeyboardProfileName) < 0 {
-		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
-		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
-	}
-
 	if devices.GetDevice(req.DeviceId) == nil {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	// Run it
-	status := devices.ChangeKeyboardProfile(req.DeviceId, req.KeyboardProfileName)
+	status := devices.ChangeNightMode(req.DeviceId, req.Enabled, req.NightStart, req.NightEnd)
 	switch status {
 	case 1:
-		return &Payload{Message: "Keyboard profile successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Night mode successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Invalid night mode time. Use HH:MM format", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Night mode start and end time can not be the same", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change keyboard profile", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change night mode", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeUserProfile will process POST request from a client for device profile change
-func ProcessChangeUserProfile(r *http.Request) *Payload {
+// ProcessChangeColorOrder will process POST request from a client for device color byte order change
+func ProcessChangeColorOrder(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -796,39 +814,27 @@ func ProcessChangeUserProfile(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.UserProfileName) < 0 {
-		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.UserProfileName); !m {
-		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
-	}
-
 	if devices.GetDevice(req.DeviceId) == nil {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	// Run it
-	status := devices.ChangeUserProfile(req.DeviceId, req.UserProfileName)
+	status := devices.ChangeColorOrder(req.DeviceId, req.ColorOrder)
 	switch status {
 	case 1:
-		return &Payload{Message: "User profile successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Color order successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change user profile. Please try again", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Color order must be RGB, BGR or GRB", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change user profile", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change color order", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessBrightnessChange will process POST request from a client for device brightness change
-func ProcessBrightnessChange(r *http.Request) *Payload {
+// ProcessChangeDialVolumeStep will process POST request from a client for control dial volume step change
+func ProcessChangeDialVolumeStep(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -840,16 +846,12 @@ func ProcessBrightnessChange(r *http.Request) *Payload {
 		}
 	}
 
-	if req.Brightness < 0 || req.Brightness > 4 {
-		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	if req.VolumeStep < 1 || req.VolumeStep > 25 {
+		return &Payload{Message: "Volume step must be between 1 and 25", Code: http.StatusOK, Status: 0}
 	}
 
 	if devices.GetDevice(req.DeviceId) == nil {
@@ -857,18 +859,20 @@ func ProcessBrightnessChange(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.ChangeDeviceBrightness(req.DeviceId, req.Brightness)
+	status := devices.ChangeDialVolumeStep(req.DeviceId, req.VolumeStep)
 	switch status {
 	case 1:
-		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Volume step successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change device brightness. You have exceeded maximum amount of LED channels per physical port", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Volume step must be between 1 and 25", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Volume step must be a multiple of 5 unless pactlVolume is enabled in config", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change volume step", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessBrightnessChangeGradual will process POST request from a client for device brightness change via defined number from 0-100
-func ProcessBrightnessChangeGradual(r *http.Request) *Payload {
+// ProcessChangeDialAcceleration will process POST request from a client for brightness dial acceleration change
+func ProcessChangeDialAcceleration(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -880,16 +884,12 @@ func ProcessBrightnessChangeGradual(r *http.Request) *Payload {
 		}
 	}
 
-	if req.Brightness < 0 || req.Brightness > 100 {
-		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	if req.DialAcceleration < 1 || req.DialAcceleration > 10 {
+		return &Payload{Message: "Dial acceleration must be between 1 and 10", Code: http.StatusOK, Status: 0}
 	}
 
 	if devices.GetDevice(req.DeviceId) == nil {
@@ -897,18 +897,18 @@ func ProcessBrightnessChangeGradual(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.ChangeDeviceBrightnessGradual(req.DeviceId, req.Brightness)
+	status := devices.ChangeDialAcceleration(req.DeviceId, req.DialAcceleration)
 	switch status {
 	case 1:
-		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Dial acceleration successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change device brightness. You have exceeded maximum amount of LED channels per physical port", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Dial acceleration must be between 1 and 10", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change dial acceleration", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessPositionChange will process POST request from a client for device position change
-func ProcessPositionChange(r *http.Request) *Payload {
+// ProcessChangeWaveDirection will process POST request from a client for wave RGB mode direction change
+func ProcessChangeWaveDirection(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -920,15 +920,7 @@ func ProcessPositionChange(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
-	if req.Direction < 0 || req.Direction > 1 {
-		return &Payload{Message: "Non-existing direction", Code: http.StatusOK, Status: 0}
-	}
-
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.DeviceId); !m {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
@@ -937,20 +929,20 @@ func ProcessPositionChange(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.UpdateDevicePosition(req.DeviceId, req.Position, req.Direction)
+	status := devices.ChangeWaveDirection(req.DeviceId, req.Direction, req.KeyId)
 	switch status {
-	case 0:
-		return &Payload{Message: "Unable to change device position. Invalid position selected", Code: http.StatusOK, Status: 0}
 	case 1:
-		return &Payload{Message: "Device position successfully changed", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Wave direction successfully changed", Code: http.StatusOK, Status: 1}
 	case 2:
-		return &Payload{Message: "Unable to change device position. Invalid position selected", Code: http.StatusOK, Status: 0}
+		return &Payload{Message: "Wave direction is not supported by this device", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Wave origin key does not exist", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change wave direction", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessLabelChange will process POST request from a client for label change
-func ProcessLabelChange(r *http.Request) *Payload {
+// ProcessSetAnimatedKeys will process POST request from a client for RGB animation key mask change
+func ProcessSetAnimatedKeys(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -962,52 +954,28 @@ func ProcessLabelChange(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.Label) < 1 {
-		return &Payload{Message: "Invalid label", Code: http.StatusOK, Status: 0}
-	}
-
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9#.:_ -]*$", req.Label); !m {
-		return &Payload{Message: "Detected invalid characters in label", Code: http.StatusOK, Status: 0}
-	}
-
-	if req.DeviceType < 0 || req.DeviceType > 1 {
-		return &Payload{Message: "Non-existing device type", Code: http.StatusOK, Status: 0}
-	}
-
-	if len(req.DeviceId) < 0 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if req.ChannelId < -1 {
-		return &Payload{Message: "Non-existing channelId", Code: http.StatusOK, Status: 0}
-	}
-
 	if devices.GetDevice(req.DeviceId) == nil {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	// Run it
-	status := devices.UpdateDeviceLabel(req.DeviceId, req.ChannelId, req.Label, req.DeviceType)
+	status := devices.SetAnimatedKeys(req.DeviceId, req.AnimatedKeys)
 	switch status {
-	case 0:
-		return &Payload{Message: "Unable to apply new label. Please try again", Code: http.StatusOK, Status: 0}
 	case 1:
-		return &Payload{Message: "Device label is successfully applied", Code: http.StatusOK, Status: 1}
+		return &Payload{Message: "Animated keys successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "One or more keys do not exist", Code: http.StatusOK, Status: 0}
 	}
-	return &Payload{Message: "Unable to apply speed profile", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change animated keys", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessManualChangeSpeed will process POST request from a client for fan/pump speed change
-func ProcessManualChangeSpeed(r *http.Request) *Payload {
+// ProcessChangeDialRotateAction will process POST request from a client for control dial rotate action change
+func ProcessChangeDialRotateAction(r *http.Request) *Payload {
 	req := &Payload{}
-	if !config.GetConfig().Manual {
-		return &Payload{Message: "Manual flag in config.json is not set to true", Code: http.StatusMethodNotAllowed, Status: 0}
-	}
-
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
@@ -1018,36 +986,27 @@ func ProcessManualChangeSpeed(r *http.Request) *Payload {
 		}
 	}
 
-	if req.Value > 100 {
-		req.Value = 100
-	}
-
-	if len(req.DeviceId) < 1 {
-		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
-	}
-
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.DeviceId); !m {
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
-	if req.ChannelId < -1 {
-		return &Payload{Message: "Non-existing channelId", Code: http.StatusOK, Status: 0}
-	}
-
 	if devices.GetDevice(req.DeviceId) == nil {
 		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	// Run it
-	if devices.UpdateManualSpeed(req.DeviceId, req.ChannelId, req.Value) == 1 {
-		return &Payload{Message: "Device speed profile is successfully changed", Code: http.StatusOK, Status: 1}
+	status := devices.ChangeDialRotateAction(req.DeviceId, req.RotateAction)
+	switch status {
+	case 1:
+		return &Payload{Message: "Dial rotate action successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Dial rotate action is not supported by this device", Code: http.StatusOK, Status: 0}
 	}
-
-	return &Payload{Message: "Unable to update device speed. Device is either unavailable or device does not have speed control", Code: http.StatusOK, Status: 0}
+	return &Payload{Message: "Unable to change dial rotate action", Code: http.StatusOK, Status: 0}
 }
 
-// ProcessChangeColor will process POST request from a client for RGB profile change
-func ProcessChangeColor(r *http.Request) *Payload {
+// ProcessChangeDialClickAction will process POST request from a client for control dial click action change
+func ProcessChangeDialClickAction(r *http.Request) *Payload {
 	req := &Payload{}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -1059,12 +1018,8 @@ func ProcessChangeColor(r *http.Request) *Payload {
 		}
 	}
 
-	if len(req.Profile) < 1 {
-		return &Payload{Message: "Non-existing speed profile", Code: http.StatusOK, Status: 0}
-	}
-
-	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.Profile); !m {
-		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
 	}
 
 	if devices.GetDevice(req.DeviceId) == nil {
@@ -1072,11 +1027,1283 @@ func ProcessChangeColor(r *http.Request) *Payload {
 	}
 
 	// Run it
-	status := devices.UpdateRgbProfile(req.DeviceId, req.ChannelId, req.Profile)
-
+	status := devices.ChangeDialClickAction(req.DeviceId, req.ClickAction)
 	switch status {
-	case 0:
-		return &Payload{Message: "Unable to change device RGB profile", Code: http.StatusOK, Status: 0}
+	case 1:
+		return &Payload{Message: "Dial click action successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Dial click action is not supported by this device", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change dial click action", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeDialLongPress will process POST request from a client for control dial long press action change
+func ProcessChangeDialLongPress(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeDialLongPress(req.DeviceId, req.LongPressAction)
+	switch status {
+	case 1:
+		return &Payload{Message: "Dial long press action successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Dial long press action is not supported by this device", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change dial long press action", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeDialInvert will process POST request from a client for control dial rotation direction change
+func ProcessChangeDialInvert(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeDialInvert(req.DeviceId, req.DialInvert)
+	switch status {
+	case 1:
+		return &Payload{Message: "Dial direction successfully changed", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to change dial direction", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeRowBrightness will process POST request from a client for keyboard row brightness change
+func ProcessChangeRowBrightness(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if req.Brightness < 1 || req.Brightness > 100 {
+		return &Payload{Message: "Row brightness must be between 1 and 100", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeRowBrightness(req.DeviceId, req.RowId, req.Brightness)
+	switch status {
+	case 1:
+		return &Payload{Message: "Row brightness successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing keyboard row", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Row brightness must be between 1 and 100", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change row brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeGpuSensor will process POST request from a client for GPU temperature sensor change
+func ProcessChangeGpuSensor(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.GpuSensor) > 0 {
+		if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.GpuSensor); !m {
+			return &Payload{Message: "Non-existing GPU sensor", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeGpuSensor(req.DeviceId, req.GpuSensor)
+	switch status {
+	case 1:
+		return &Payload{Message: "GPU sensor successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing GPU sensor", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change GPU sensor", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeProfileMetadata will process POST request from a client for user profile metadata change
+func ProcessChangeProfileMetadata(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeProfileMetadata(req.DeviceId, req.Description, req.Tags)
+	switch status {
+	case 1:
+		return &Payload{Message: "Profile metadata successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Profile description is too long", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Too many profile tags or tag is too long", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change profile metadata", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeBootProfile will process POST request from a client for boot profile change
+func ProcessChangeBootProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.UserProfileName) > 0 {
+		if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.UserProfileName); !m {
+			return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeBootProfile(req.DeviceId, req.UserProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "Boot profile successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing user profile", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change boot profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessSaveDeviceProfile will process PUT request from a client for device profile save
+func ProcessSaveDeviceProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.KeyboardProfileName) < 0 {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.SaveDeviceProfile(req.DeviceId, req.KeyboardProfileName, req.New)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard profile successfully saved", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to save keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to save keyboard profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeKeyboardLayout will process POST request from a client for device layout change
+func ProcessChangeKeyboardLayout(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.KeyboardLayout) < 1 {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardLayout); !m {
+		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeKeyboardLayout(req.DeviceId, req.KeyboardLayout)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard layout successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change keyboard layout. Please try again", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change keyboard layout", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeControlDial will process POST request from a client for device control dial change
+func ProcessChangeControlDial(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.KeyboardControlDial < 0 {
+		return &Payload{Message: "Invalid control dial option", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeKeyboardControlDial(req.DeviceId, req.KeyboardControlDial)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard control dial successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Control dial option is not supported by this device", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change keyboard control dial", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeSleepMode will process POST request from a client for device sleep change
+func ProcessChangeSleepMode(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.SleepMode < 1 || req.SleepMode > 60 {
+		return &Payload{Message: "Invalid sleep option", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeDeviceSleepMode(req.DeviceId, req.SleepMode)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device sleep mode successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change device sleep mode. Please try again", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device sleep mode", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessDeleteKeyboardProfile will process DELETE request from a client for device profile deletion
+func ProcessDeleteKeyboardProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.KeyboardProfileName) < 0 {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.DeleteKeyboardProfile(req.DeviceId, req.KeyboardProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard profile successfully deleted", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to delete keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Default keyboard profile can not be deleted", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to save keyboard profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessRenameKeyboardProfile will process POST request from a client for keyboard profile rename
+func ProcessRenameKeyboardProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.KeyboardProfileName); !m {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.NewProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters, numbers and dashes", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.RenameKeyboardProfile(req.DeviceId, req.KeyboardProfileName, req.NewProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard profile successfully renamed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing keyboard profile", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Default keyboard profile can not be renamed", Code: http.StatusOK, Status: 0}
+	case 4:
+		return &Payload{Message: "Keyboard profile with this name already exists", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to rename keyboard profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessCloneKeyboardProfile will process POST request from a client for keyboard profile clone
+func ProcessCloneKeyboardProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.KeyboardProfileName); !m {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.NewProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters, numbers and dashes", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.CloneKeyboardProfile(req.DeviceId, req.KeyboardProfileName, req.NewProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard profile successfully cloned", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing keyboard profile", Code: http.StatusOK, Status: 0}
+	case 4:
+		return &Payload{Message: "Keyboard profile with this name already exists", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to clone keyboard profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeAppBindings will process POST request from a client for application keyboard profile bindings
+func ProcessChangeAppBindings(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	for executable, profileName := range req.AppBindings {
+		if len(executable) == 0 || len(executable) > 255 {
+			return &Payload{Message: "Invalid application name", Code: http.StatusOK, Status: 0}
+		}
+		if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", profileName); !m {
+			return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	if len(req.FallbackProfile) > 0 {
+		if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.FallbackProfile); !m {
+			return &Payload{Message: "Invalid fallback profile name", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeAppBindings(req.DeviceId, req.AppBindings, req.FallbackProfile)
+	switch status {
+	case 1:
+		return &Payload{Message: "Application bindings successfully updated", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing keyboard profile", Code: http.StatusOK, Status: 0}
+	case 4:
+		return &Payload{Message: "Application name can not be empty or contain a path", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to update application bindings", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessResetDeviceProfile will process POST request from a client for device profile reset
+func ProcessResetDeviceProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ResetDeviceProfile(req.DeviceId)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device profile successfully reset", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to reset device profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeMirrorTarget will process POST request from a client for RGB mirror target change
+func ProcessChangeMirrorTarget(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.MirrorTarget) > 0 {
+		if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.MirrorTarget); !m {
+			return &Payload{Message: "Non-existing mirror target", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeMirrorTarget(req.DeviceId, req.MirrorTarget)
+	switch status {
+	case 1:
+		return &Payload{Message: "Mirror target successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing mirror target", Code: http.StatusOK, Status: 0}
+	case 3:
+		return &Payload{Message: "Device can not mirror itself or create a mirror loop", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change mirror target", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessReinitLeds will process POST request from a client for device LED re-initialization
+func ProcessReinitLeds(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	if devices.ReinitLeds(req.DeviceId) == 1 {
+		return &Payload{Message: "Device LEDs successfully re-initialized", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to re-initialize device LEDs", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessIdentifyDevice will process POST request from a client for device identify
+func ProcessIdentifyDevice(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.IdentifyDevice(req.DeviceId)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device identify started", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Device identify is already running", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to identify device", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessRawTransfer will process POST request from a client for raw device packet transfer.
+// Endpoint and buffer are hex encoded, device output is returned as hex encoded data
+func ProcessRawTransfer(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	endpoint, err := hex.DecodeString(strings.ReplaceAll(req.Endpoint, " ", ""))
+	if err != nil || len(endpoint) < 1 {
+		return &Payload{Message: "Invalid endpoint. Endpoint has to be hex encoded", Code: http.StatusOK, Status: 0}
+	}
+
+	buffer, err := hex.DecodeString(strings.ReplaceAll(req.Buffer, " ", ""))
+	if err != nil {
+		return &Payload{Message: "Invalid buffer. Buffer has to be hex encoded", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	response, err := devices.RawTransfer(req.DeviceId, endpoint, buffer, req.Command)
+	if err != nil {
+		return &Payload{Message: fmt.Sprintf("Unable to transfer data: %s", err.Error()), Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Data successfully transferred", Code: http.StatusOK, Status: 1, Data: hex.EncodeToString(response)}
+}
+
+// ProcessSelfTest will process POST request from a client for device self-test
+func ProcessSelfTest(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	if err = devices.SelfTest(req.DeviceId); err != nil {
+		return &Payload{Message: fmt.Sprintf("Device self-test failed: %s", err.Error()), Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Device self-test passed", Code: http.StatusOK, Status: 1}
+}
+
+// ProcessReloadProfiles will process POST request from a client for reloading device profiles from disk
+func ProcessReloadProfiles(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ReloadProfiles(req.DeviceId)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device profiles successfully reloaded", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to reload device profiles", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeKeyboardProfile will process POST request from a client for keyboard profile change
+func ProcessChangeKeyboardProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.KeyboardProfileName) < 0 {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.KeyboardProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeKeyboardProfile(req.DeviceId, req.KeyboardProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard profile successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change keyboard profile. Please try again", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change keyboard profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeUserProfile will process POST request from a client for device profile change
+func ProcessChangeUserProfile(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.UserProfileName) < 0 {
+		return &Payload{Message: "Invalid profile name", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.UserProfileName); !m {
+		return &Payload{Message: "Profile name can contain only letters and numbers", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeUserProfile(req.DeviceId, req.UserProfileName)
+	switch status {
+	case 1:
+		return &Payload{Message: "User profile successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change user profile. Please try again", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change user profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessBrightnessChange will process POST request from a client for device brightness change
+func ProcessBrightnessChange(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.Brightness < 0 || req.Brightness > 4 {
+		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeDeviceBrightness(req.DeviceId, req.Brightness)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change device brightness. You have exceeded maximum amount of LED channels per physical port", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessBrightnessChangeGradual will process POST request from a client for device brightness change via defined number from 0-100
+func ProcessBrightnessChangeGradual(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.Brightness < 0 || req.Brightness > 100 {
+		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.ChangeDeviceBrightnessGradual(req.DeviceId, req.Brightness)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change device brightness. You have exceeded maximum amount of LED channels per physical port", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessBrightnessPercent will process POST request from a client for device brightness change via percentage from 0-100
+func ProcessBrightnessPercent(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.Brightness > 100 {
+		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.SetBrightnessPercent(req.DeviceId, req.Brightness)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Invalid brightness value", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessBrightnessLevel will process POST request from a client for device brightness level change from 0-1000
+func ProcessBrightnessLevel(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.BrightnessLevel > 1000 {
+		return &Payload{Message: "Invalid brightness level", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.SetBrightnessLevel(req.DeviceId, req.BrightnessLevel)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device brightness successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Invalid brightness level", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessPositionChange will process POST request from a client for device position change
+func ProcessPositionChange(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if req.Direction < 0 || req.Direction > 1 {
+		return &Payload{Message: "Non-existing direction", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.UpdateDevicePosition(req.DeviceId, req.Position, req.Direction)
+	switch status {
+	case 0:
+		return &Payload{Message: "Unable to change device position. Invalid position selected", Code: http.StatusOK, Status: 0}
+	case 1:
+		return &Payload{Message: "Device position successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Unable to change device position. Invalid position selected", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device brightness", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessLabelChange will process POST request from a client for label change
+func ProcessLabelChange(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.Label) < 1 {
+		return &Payload{Message: "Invalid label", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9#.:_ -]*$", req.Label); !m {
+		return &Payload{Message: "Detected invalid characters in label", Code: http.StatusOK, Status: 0}
+	}
+
+	if req.DeviceType < 0 || req.DeviceType > 1 {
+		return &Payload{Message: "Non-existing device type", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.DeviceId) < 0 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if req.ChannelId < -1 {
+		return &Payload{Message: "Non-existing channelId", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.UpdateDeviceLabel(req.DeviceId, req.ChannelId, req.Label, req.DeviceType)
+	switch status {
+	case 0:
+		return &Payload{Message: "Unable to apply new label. Please try again", Code: http.StatusOK, Status: 0}
+	case 1:
+		return &Payload{Message: "Device label is successfully applied", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to apply speed profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessManualChangeSpeed will process POST request from a client for fan/pump speed change
+func ProcessManualChangeSpeed(r *http.Request) *Payload {
+	req := &Payload{}
+	if !config.GetConfig().Manual {
+		return &Payload{Message: "Manual flag in config.json is not set to true", Code: http.StatusMethodNotAllowed, Status: 0}
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if req.Value > 100 {
+		req.Value = 100
+	}
+
+	if len(req.DeviceId) < 1 {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if req.ChannelId < -1 {
+		return &Payload{Message: "Non-existing channelId", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	if devices.UpdateManualSpeed(req.DeviceId, req.ChannelId, req.Value) == 1 {
+		return &Payload{Message: "Device speed profile is successfully changed", Code: http.StatusOK, Status: 1}
+	}
+
+	return &Payload{Message: "Unable to update device speed. Device is either unavailable or device does not have speed control", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessPreviewColor will process POST request from a client for RGB profile preview
+func ProcessPreviewColor(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.Profile); !m {
+		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.PreviewRgbProfile(req.DeviceId, req.Profile)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device RGB profile preview started", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to preview device RGB profile", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessCancelPreviewColor will process POST request from a client for RGB profile preview cancel
+func ProcessCancelPreviewColor(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.CancelRgbPreview(req.DeviceId)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device RGB profile preview stopped", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Device RGB profile preview is not active", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to stop device RGB profile preview", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessStopAllRgb will process POST request from a client to turn off LEDs on all devices
+func ProcessStopAllRgb(_ *http.Request) *Payload {
+	status := devices.StopAllRGB()
+	switch status {
+	case 1:
+		return &Payload{Message: "Device LEDs are turned off", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "No device supports turning off LEDs", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessChangeColor will process POST request from a client for RGB profile change
+func ProcessChangeColor(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if len(req.Profile) < 1 {
+		return &Payload{Message: "Non-existing speed profile", Code: http.StatusOK, Status: 0}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.Profile); !m {
+		return &Payload{Message: "Non-existing RGB profile", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	// Run it
+	status := devices.UpdateRgbProfile(req.DeviceId, req.ChannelId, req.Profile)
+
+	switch status {
+	case 0:
+		return &Payload{Message: "Unable to change device RGB profile", Code: http.StatusOK, Status: 0}
 	case 2:
 		return &Payload{Message: "Unable to change device RGB profile. This profile requires a pump or AIO", Code: http.StatusOK, Status: 0}
 	case 3:
@@ -1231,6 +2458,121 @@ func ProcessKeyboardColor(r *http.Request) *Payload {
 	return &Payload{Message: "Unable to change device color", Code: http.StatusOK, Status: 0}
 }
 
+// ProcessKeyboardColorHex will process POST request from a client for keyboard color change via hex string
+func ProcessKeyboardColorHex(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	status := devices.UpdateKeyboardColorHex(req.DeviceId, req.ColorHex)
+	switch status {
+	case 1:
+		return &Payload{Message: "Device color is successfully changed", Code: http.StatusOK, Status: 1}
+	case 3:
+		return &Payload{Message: "Invalid color. Color must be in #RRGGBB format", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change device color", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessKeyColorMap will process POST request from a client for multiple keyboard key colors change
+func ProcessKeyColorMap(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if len(req.KeyColors) == 0 {
+		return &Payload{Message: "No key colors selected", Code: http.StatusOK, Status: 0}
+	}
+
+	for _, color := range req.KeyColors {
+		if color.Red > 255 || color.Green > 255 || color.Blue > 255 {
+			return &Payload{Message: "Invalid color selected", Code: http.StatusOK, Status: 0}
+		}
+
+		if color.Red < 0 || color.Green < 0 || color.Blue < 0 {
+			return &Payload{Message: "Invalid color selected", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	status := devices.UpdateKeyColorMap(req.DeviceId, req.KeyColors)
+	switch status {
+	case 1:
+		return &Payload{Message: "Key colors are successfully changed", Code: http.StatusOK, Status: 1}
+	case 2:
+		return &Payload{Message: "Non-existing key selected", Code: http.StatusOK, Status: 0}
+	}
+	return &Payload{Message: "Unable to change key colors", Code: http.StatusOK, Status: 0}
+}
+
+// ProcessKeyboardGradient will process POST request from a client for keyboard gradient change
+func ProcessKeyboardGradient(r *http.Request) *Payload {
+	req := &Payload{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Log(map[string]interface{}{"error": err}).Error("Unable to decode JSON")
+		return &Payload{
+			Message: "Unable to validate your request. Please try again!",
+			Code:    http.StatusOK,
+			Status:  0,
+		}
+	}
+
+	if m, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", req.DeviceId); !m {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	if devices.GetDevice(req.DeviceId) == nil {
+		return &Payload{Message: "Non-existing device", Code: http.StatusOK, Status: 0}
+	}
+
+	for _, color := range []rgb.Color{req.StartColor, req.EndColor} {
+		if color.Red > 255 || color.Green > 255 || color.Blue > 255 {
+			return &Payload{Message: "Invalid color selected", Code: http.StatusOK, Status: 0}
+		}
+
+		if color.Red < 0 || color.Green < 0 || color.Blue < 0 {
+			return &Payload{Message: "Invalid color selected", Code: http.StatusOK, Status: 0}
+		}
+	}
+
+	status := devices.UpdateKeyboardGradient(req.DeviceId, req.StartColor, req.EndColor)
+	switch status {
+	case 1:
+		return &Payload{Message: "Keyboard gradient is successfully changed", Code: http.StatusOK, Status: 1}
+	}
+	return &Payload{Message: "Unable to change keyboard gradient", Code: http.StatusOK, Status: 0}
+}
+
 // ProcessMiscColor will process a POST request from a client for misc device color change
 func ProcessMiscColor(r *http.Request) *Payload {
 	req := &Payload{}
@@ -114,6 +114,27 @@ func getGpuTemperatureClean(w http.ResponseWriter, _ *http.Request) {
 	resp.Send(w)
 }
 
+// getGpuSensors will return a list of available GPU temperature sensors
+func getGpuSensors(w http.ResponseWriter, _ *http.Request) {
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   temperatures.GetGpuSensors(),
+	}
+	resp.Send(w)
+}
+
+// changeGpuSensor handles change of GPU temperature sensor
+func changeGpuSensor(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeGpuSensor(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // getStorageTemperature will return current storage temperature
 func getStorageTemperature(w http.ResponseWriter, _ *http.Request) {
 	resp := &Response{
@@ -149,6 +170,144 @@ func getDevice(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getRgbModes returns response on /rgbModes
+func getRgbModes(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	modes := devices.GetRgbModes(deviceId)
+	if modes == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device has no RGB modes",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   modes,
+	}
+	resp.Send(w)
+}
+
+// getDeviceStatus returns response on /status
+func getDeviceStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	status := devices.GetDeviceStatus(deviceId)
+	if status == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device status is not supported",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   status,
+	}
+	resp.Send(w)
+}
+
+// getKeyColors returns response on /keyColors
+func getKeyColors(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	colors := devices.GetKeyColors(deviceId)
+	if colors == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device has no per-key colors",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   colors,
+	}
+	resp.Send(w)
+}
+
+// getLayoutGeometry returns response on /layoutGeometry
+func getLayoutGeometry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	geometry := devices.GetLayoutGeometry(deviceId)
+	if geometry == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device has no keyboard layout",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   geometry,
+	}
+	resp.Send(w)
+}
+
+// listDevices returns response on /subDevices
+func listDevices(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	list := devices.ListDevices(deviceId)
+	if list == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device has no sub-devices",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   list,
+	}
+	resp.Send(w)
+}
+
+// getKeyboardLayouts returns response on /layouts
+func getKeyboardLayouts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId := vars["deviceId"]
+	layouts := devices.GetKeyboardLayouts(deviceId)
+	if layouts == nil {
+		resp := &Response{
+			Code:    http.StatusOK,
+			Status:  0,
+			Message: "Non-existing device or device has no keyboard layouts",
+		}
+		resp.Send(w)
+		return
+	}
+
+	resp := &Response{
+		Code:   http.StatusOK,
+		Status: 1,
+		Data:   layouts,
+	}
+	resp.Send(w)
+}
+
 // getTemperatures returns response on /temperatures
 func getTemperature(w http.ResponseWriter, r *http.Request) {
 	resp := &Response{}
@@ -220,6 +379,17 @@ func newTemperatureProfile(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// changeTemperaturePollInterval handles temperature polling interval change
+func changeTemperaturePollInterval(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessTemperaturePollInterval(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // deleteTemperatureProfile handles deletion of temperature profile
 func deleteTemperatureProfile(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessDeleteTemperatureProfile(r)
@@ -319,6 +489,69 @@ func saveUserProfile(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// importUserProfile handles importing user profiles from a file
+func importUserProfile(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessImportUserProfile(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// exportUserProfile handles user profile download
+func exportUserProfile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId, valid := vars["deviceId"]
+	if !valid {
+		resp := &Response{Code: http.StatusOK, Status: 0, Message: "Non-existing device"}
+		resp.Send(w)
+		return
+	}
+
+	data, filename, err := devices.ExportUserProfile(deviceId)
+	if err != nil {
+		resp := &Response{Code: http.StatusOK, Status: 0, Message: "Unable to export user profile"}
+		resp.Send(w)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "deviceId": deviceId}).Error("Unable to send user profile")
+	}
+}
+
+// dumpDiagnostics handles device diagnostics download
+func dumpDiagnostics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deviceId, valid := vars["deviceId"]
+	if !valid {
+		resp := &Response{Code: http.StatusOK, Status: 0, Message: "Non-existing device"}
+		resp.Send(w)
+		return
+	}
+
+	data, err := devices.DumpDiagnostics(deviceId)
+	if err != nil {
+		resp := &Response{Code: http.StatusOK, Status: 0, Message: "Unable to collect device diagnostics"}
+		resp.Send(w)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=%q", deviceId+"-diagnostics.json"))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	if err != nil {
+		logger.Log(logger.Fields{"error": err, "deviceId": deviceId}).Error("Unable to send device diagnostics")
+	}
+}
+
 // changeUserProfile handles user profile change
 func changeUserProfile(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessChangeUserProfile(r)
@@ -330,6 +563,28 @@ func changeUserProfile(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// changeProfileMetadata handles user profile description and tags change
+func changeProfileMetadata(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeProfileMetadata(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeBootProfile handles user boot profile change
+func changeBootProfile(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeBootProfile(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // changeBrightness handles user brightness change
 func changeBrightness(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessBrightnessChange(r)
@@ -352,6 +607,28 @@ func changeBrightnessGradual(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// changeBrightnessLevel handles user brightness change via level from 0-1000
+func changeBrightnessLevel(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessBrightnessLevel(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeBrightnessPercent handles user brightness change via percentage from 0-100
+func changeBrightnessPercent(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessBrightnessPercent(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // changePosition handles device position change
 func changePosition(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessPositionChange(r)
@@ -385,6 +662,39 @@ func setDeviceColor(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// previewDeviceColor handles device RGB profile preview
+func previewDeviceColor(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessPreviewColor(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// cancelPreviewDeviceColor handles device RGB profile preview cancel
+func cancelPreviewDeviceColor(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessCancelPreviewColor(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// stopAllRgb handles LEDs turn off on all devices
+func stopAllRgb(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessStopAllRgb(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // setDeviceStrip handles device RGB strip changes
 func setDeviceStrip(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessChangeStrip(r)
@@ -450,6 +760,39 @@ func setDashboardSettings(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// setKeyboardColorHex handles keyboard color change via hex string
+func setKeyboardColorHex(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessKeyboardColorHex(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// setKeyColorMap handles multiple keyboard key colors change
+func setKeyColorMap(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessKeyColorMap(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// setKeyboardGradient handles keyboard gradient change
+func setKeyboardGradient(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessKeyboardGradient(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // setKeyboardColor handles keyboard color change
 func setKeyboardColor(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessKeyboardColor(r)
@@ -505,6 +848,171 @@ func changeControlDial(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// changeRgbFrameDelay handles RGB frame delay change
+func changeRgbFrameDelay(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeRgbFrameDelay(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeTemperatureRange handles device temperature range change for temperature RGB modes
+func changeTemperatureRange(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeTemperatureRange(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeIdleDimming handles device idle dimming change
+func changeIdleDimming(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeIdleDimming(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeNightMode handles device night mode schedule change
+func changeNightMode(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeNightMode(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeColorOrder handles device color byte order change
+func changeColorOrder(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeColorOrder(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialVolumeStep handles keyboard control dial volume step change
+func changeDialVolumeStep(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialVolumeStep(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialAcceleration handles keyboard brightness dial acceleration change
+func changeDialAcceleration(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialAcceleration(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeWaveDirection handles keyboard wave RGB mode direction change
+func changeWaveDirection(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeWaveDirection(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// setAnimatedKeys handles keyboard RGB animation key mask change
+func setAnimatedKeys(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessSetAnimatedKeys(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialRotateAction handles keyboard control dial rotate action change
+func changeDialRotateAction(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialRotateAction(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialClickAction handles keyboard control dial click action change
+func changeDialClickAction(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialClickAction(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialLongPress handles keyboard control dial long press action change
+func changeDialLongPress(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialLongPress(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeDialInvert handles keyboard control dial rotation direction change
+func changeDialInvert(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeDialInvert(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeRowBrightness handles keyboard row brightness change
+func changeRowBrightness(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeRowBrightness(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeRgbIntensity handles RGB effect intensity change
+func changeRgbIntensity(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeRgbIntensity(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // changeSleepMode handles keyboard sleep mode change
 func changeSleepMode(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessChangeSleepMode(r)
@@ -538,6 +1046,117 @@ func deleteKeyboardProfile(w http.ResponseWriter, r *http.Request) {
 	resp.Send(w)
 }
 
+// cloneKeyboardProfile handles keyboard profile clone
+func cloneKeyboardProfile(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessCloneKeyboardProfile(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeAppBindings handles keyboard profiles bound to running applications
+func changeAppBindings(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeAppBindings(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// renameKeyboardProfile handles keyboard profile rename
+func renameKeyboardProfile(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessRenameKeyboardProfile(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// reloadProfiles handles reloading of device profiles from disk
+func reloadProfiles(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessReloadProfiles(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// rawTransfer handles raw packet transfer to a device running in debug mode
+func rawTransfer(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessRawTransfer(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+		Data:    request.Data,
+	}
+	resp.Send(w)
+}
+
+// selfTest handles device self-test
+func selfTest(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessSelfTest(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// changeMirrorTarget handles device RGB mirror target change
+func changeMirrorTarget(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessChangeMirrorTarget(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// reinitLeds handles device LED re-initialization
+func reinitLeds(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessReinitLeds(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// identifyDevice handles device identify
+func identifyDevice(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessIdentifyDevice(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
+// resetDeviceProfile handles reset of active device profile to default values
+func resetDeviceProfile(w http.ResponseWriter, r *http.Request) {
+	request := requests.ProcessResetDeviceProfile(r)
+	resp := &Response{
+		Code:    request.Code,
+		Status:  request.Status,
+		Message: request.Message,
+	}
+	resp.Send(w)
+}
+
 // changeKeyboardProfile handles keyboard profile change
 func changeKeyboardProfile(w http.ResponseWriter, r *http.Request) {
 	request := requests.ProcessChangeKeyboardProfile(r)
@@ -795,12 +1414,42 @@ func setRoutes() *mux.Router {
 		HandlerFunc(getGpuTemperature)
 	r.Methods(http.MethodGet).Path("/api/gpuTemp/clean").
 		HandlerFunc(getGpuTemperatureClean)
+	r.Methods(http.MethodGet).Path("/api/gpuTemp/sensors").
+		HandlerFunc(getGpuSensors)
+	r.Methods(http.MethodPost).Path("/api/gpuTemp/sensor").
+		HandlerFunc(changeGpuSensor)
 	r.Methods(http.MethodGet).Path("/api/storageTemp").
 		HandlerFunc(getStorageTemperature)
 	r.Methods(http.MethodGet).Path("/api/devices").
 		HandlerFunc(getDevice)
 	r.Methods(http.MethodGet).Path("/api/devices/{deviceOd}").
 		HandlerFunc(getDevice)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/rgbModes").
+		HandlerFunc(getRgbModes)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/status").
+		HandlerFunc(getDeviceStatus)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/keyColors").
+		HandlerFunc(getKeyColors)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/layoutGeometry").
+		HandlerFunc(getLayoutGeometry)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/layouts").
+		HandlerFunc(getKeyboardLayouts)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/subDevices").
+		HandlerFunc(listDevices)
+	r.Methods(http.MethodGet).Path("/api/devices/{deviceId}/diagnostics").
+		HandlerFunc(dumpDiagnostics)
+	r.Methods(http.MethodPost).Path("/api/devices/identify").
+		HandlerFunc(identifyDevice)
+	r.Methods(http.MethodPost).Path("/api/devices/reinitLeds").
+		HandlerFunc(reinitLeds)
+	r.Methods(http.MethodPost).Path("/api/devices/mirror").
+		HandlerFunc(changeMirrorTarget)
+	r.Methods(http.MethodPost).Path("/api/devices/reloadProfiles").
+		HandlerFunc(reloadProfiles)
+	r.Methods(http.MethodPost).Path("/api/devices/rawTransfer").
+		HandlerFunc(rawTransfer)
+	r.Methods(http.MethodPost).Path("/api/devices/selfTest").
+		HandlerFunc(selfTest)
 	r.Methods(http.MethodGet).Path("/api/color").
 		HandlerFunc(getColor)
 	r.Methods(http.MethodGet).Path("/api/color/{profile}").
@@ -815,12 +1464,20 @@ func setRoutes() *mux.Router {
 		HandlerFunc(updateTemperatureProfile)
 	r.Methods(http.MethodDelete).Path("/api/temperatures").
 		HandlerFunc(deleteTemperatureProfile)
+	r.Methods(http.MethodPost).Path("/api/temperatures/pollInterval").
+		HandlerFunc(changeTemperaturePollInterval)
 	r.Methods(http.MethodPost).Path("/api/speed").
 		HandlerFunc(setDeviceSpeed)
 	r.Methods(http.MethodPost).Path("/api/speed/manual").
 		HandlerFunc(setManualDeviceSpeed)
 	r.Methods(http.MethodPost).Path("/api/color").
 		HandlerFunc(setDeviceColor)
+	r.Methods(http.MethodPost).Path("/api/color/preview").
+		HandlerFunc(previewDeviceColor)
+	r.Methods(http.MethodPost).Path("/api/color/preview/cancel").
+		HandlerFunc(cancelPreviewDeviceColor)
+	r.Methods(http.MethodPost).Path("/api/color/off").
+		HandlerFunc(stopAllRgb)
 	r.Methods(http.MethodPost).Path("/api/hub/strip").
 		HandlerFunc(setDeviceStrip)
 	r.Methods(http.MethodPost).Path("/api/hub/type").
@@ -841,10 +1498,24 @@ func setRoutes() *mux.Router {
 		HandlerFunc(saveUserProfile)
 	r.Methods(http.MethodPost).Path("/api/userProfile").
 		HandlerFunc(changeUserProfile)
+	r.Methods(http.MethodPut).Path("/api/userProfile/import").
+		HandlerFunc(importUserProfile)
+	r.Methods(http.MethodGet).Path("/api/userProfile/export/{deviceId}").
+		HandlerFunc(exportUserProfile)
+	r.Methods(http.MethodPost).Path("/api/userProfile/boot").
+		HandlerFunc(changeBootProfile)
+	r.Methods(http.MethodPost).Path("/api/userProfile/metadata").
+		HandlerFunc(changeProfileMetadata)
+	r.Methods(http.MethodPost).Path("/api/userProfile/reset").
+		HandlerFunc(resetDeviceProfile)
 	r.Methods(http.MethodPost).Path("/api/brightness").
 		HandlerFunc(changeBrightness)
 	r.Methods(http.MethodPost).Path("/api/brightness/gradual").
 		HandlerFunc(changeBrightnessGradual)
+	r.Methods(http.MethodPost).Path("/api/brightness/percent").
+		HandlerFunc(changeBrightnessPercent)
+	r.Methods(http.MethodPost).Path("/api/brightness/level").
+		HandlerFunc(changeBrightnessLevel)
 	r.Methods(http.MethodPost).Path("/api/position").
 		HandlerFunc(changePosition)
 	r.Methods(http.MethodGet).Path("/api/dashboard").
@@ -855,6 +1526,12 @@ func setRoutes() *mux.Router {
 		HandlerFunc(setARGBDevice)
 	r.Methods(http.MethodPost).Path("/api/keyboard/color").
 		HandlerFunc(setKeyboardColor)
+	r.Methods(http.MethodPost).Path("/api/keyboard/color/hex").
+		HandlerFunc(setKeyboardColorHex)
+	r.Methods(http.MethodPost).Path("/api/keyboard/color/gradient").
+		HandlerFunc(setKeyboardGradient)
+	r.Methods(http.MethodPost).Path("/api/keyboard/color/map").
+		HandlerFunc(setKeyColorMap)
 	r.Methods(http.MethodPost).Path("/api/misc/color").
 		HandlerFunc(setMiscColor)
 	r.Methods(http.MethodPut).Path("/api/keyboard/profile/new").
@@ -865,12 +1542,48 @@ func setRoutes() *mux.Router {
 		HandlerFunc(saveDeviceProfile)
 	r.Methods(http.MethodDelete).Path("/api/keyboard/profile/delete").
 		HandlerFunc(deleteKeyboardProfile)
+	r.Methods(http.MethodPost).Path("/api/keyboard/profile/rename").
+		HandlerFunc(renameKeyboardProfile)
+	r.Methods(http.MethodPost).Path("/api/keyboard/profile/clone").
+		HandlerFunc(cloneKeyboardProfile)
+	r.Methods(http.MethodPost).Path("/api/keyboard/profile/appBindings").
+		HandlerFunc(changeAppBindings)
 	r.Methods(http.MethodPost).Path("/api/keyboard/layout").
 		HandlerFunc(changeKeyboardLayout)
 	r.Methods(http.MethodPost).Path("/api/keyboard/dial").
 		HandlerFunc(changeControlDial)
 	r.Methods(http.MethodPost).Path("/api/keyboard/sleep").
 		HandlerFunc(changeSleepMode)
+	r.Methods(http.MethodPost).Path("/api/rgb/frameDelay").
+		HandlerFunc(changeRgbFrameDelay)
+	r.Methods(http.MethodPost).Path("/api/rgb/intensity").
+		HandlerFunc(changeRgbIntensity)
+	r.Methods(http.MethodPost).Path("/api/rgb/temperatureRange").
+		HandlerFunc(changeTemperatureRange)
+	r.Methods(http.MethodPost).Path("/api/rgb/colorOrder").
+		HandlerFunc(changeColorOrder)
+	r.Methods(http.MethodPost).Path("/api/keyboard/idleDimming").
+		HandlerFunc(changeIdleDimming)
+	r.Methods(http.MethodPost).Path("/api/keyboard/nightMode").
+		HandlerFunc(changeNightMode)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/volumeStep").
+		HandlerFunc(changeDialVolumeStep)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/acceleration").
+		HandlerFunc(changeDialAcceleration)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/invert").
+		HandlerFunc(changeDialInvert)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/longPress").
+		HandlerFunc(changeDialLongPress)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/rotate").
+		HandlerFunc(changeDialRotateAction)
+	r.Methods(http.MethodPost).Path("/api/keyboard/dial/click").
+		HandlerFunc(changeDialClickAction)
+	r.Methods(http.MethodPost).Path("/api/keyboard/wave/direction").
+		HandlerFunc(changeWaveDirection)
+	r.Methods(http.MethodPost).Path("/api/keyboard/animatedKeys").
+		HandlerFunc(setAnimatedKeys)
+	r.Methods(http.MethodPost).Path("/api/keyboard/rowBrightness").
+		HandlerFunc(changeRowBrightness)
 	r.Methods(http.MethodPost).Path("/api/scheduler/rgb").
 		HandlerFunc(changeRgbScheduler)
 	r.Methods(http.MethodPost).Path("/api/psu/speed").
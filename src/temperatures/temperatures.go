@@ -6,13 +6,17 @@ import (
 	"OpenLinkHub/src/dashboard"
 	"OpenLinkHub/src/logger"
 	"encoding/json"
+	"fmt"
 	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -51,6 +55,17 @@ type TemperatureProfileData struct {
 	Hidden    bool
 }
 
+type GpuSensor struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// temperatureCache struct contains last read temperature shared between devices
+type temperatureCache struct {
+	value   float32
+	updated time.Time
+}
+
 type StorageTemperatures struct {
 	Key               string
 	Model             string
@@ -65,6 +80,8 @@ var (
 	mutex        sync.Mutex
 	temperatures *Temperatures
 	cpuPackages  = []string{"k10temp", "zenpower", "coretemp"}
+	gpuPackages  = []string{"amdgpu", "radeon", "nouveau"}
+	nvidiaPrefix = "nvidia-"
 	// Defaults
 	profileQuiet = TemperatureProfileData{
 		Sensor: 0,
@@ -184,6 +201,13 @@ var (
 	}
 )
 
+var (
+	mutexCache          sync.Mutex
+	cpuCache            temperatureCache
+	gpuCache            = map[string]temperatureCache{}
+	defaultPollInterval = 1000
+)
+
 // Init will initialize temperature data
 func Init() {
 	pwd = config.GetConfig().ConfigPath
@@ -476,6 +500,136 @@ func GetGpuTemperature() float32 {
 	return temp
 }
 
+// GetGpuSensors will return a list of available GPU temperature sensors
+func GetGpuSensors() []GpuSensor {
+	var sensors []GpuSensor
+
+	// NVIDIA, one line per GPU
+	cmd := exec.Command("nvidia-smi", "--query-gpu=index,name", "--format=csv,noheader")
+	output, err := cmd.Output()
+	if err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			parts := strings.SplitN(line, ",", 2)
+			if len(parts) < 2 {
+				continue
+			}
+			sensors = append(sensors, GpuSensor{
+				Key:  nvidiaPrefix + strings.TrimSpace(parts[0]),
+				Name: strings.TrimSpace(parts[1]),
+			})
+		}
+	}
+
+	// AMD and open source drivers are exposed via hwmon
+	hwmonDir := "/sys/class/hwmon"
+	entries, err := os.ReadDir(hwmonDir)
+	if err != nil {
+		return sensors
+	}
+
+	for _, entry := range entries {
+		nameFile := filepath.Join(hwmonDir, entry.Name(), "name")
+		name, e := os.ReadFile(nameFile)
+		if e != nil {
+			continue
+		}
+
+		gpuPackage := strings.TrimSpace(string(name))
+		if slices.Contains(gpuPackages, gpuPackage) {
+			sensors = append(sensors, GpuSensor{
+				Key:  entry.Name(),
+				Name: fmt.Sprintf("%s (%s)", gpuPackage, entry.Name()),
+			})
+		}
+	}
+	return sensors
+}
+
+// GetPollInterval will return temperature polling interval from configuration
+func GetPollInterval() time.Duration {
+	interval := config.GetConfig().TemperaturePollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return time.Duration(interval) * time.Millisecond
+}
+
+// GetCachedCpuTemperature will return CPU temperature shared between all devices.
+// Temperature is read at most once per polling interval
+func GetCachedCpuTemperature() float32 {
+	mutexCache.Lock()
+	defer mutexCache.Unlock()
+
+	if time.Since(cpuCache.updated) < GetPollInterval() {
+		return cpuCache.value
+	}
+	cpuCache = temperatureCache{value: GetCpuTemperature(), updated: time.Now()}
+	return cpuCache.value
+}
+
+// GetCachedGpuTemperature will return GPU temperature shared between all devices
+func GetCachedGpuTemperature() float32 {
+	return GetCachedGpuTemperatureBySensor("")
+}
+
+// GetCachedGpuTemperatureBySensor will return GPU temperature of a given sensor shared between all devices.
+// Temperature is read at most once per polling interval. Empty sensor uses automatic detection
+func GetCachedGpuTemperatureBySensor(sensor string) float32 {
+	mutexCache.Lock()
+	defer mutexCache.Unlock()
+
+	if cache, ok := gpuCache[sensor]; ok && time.Since(cache.updated) < GetPollInterval() {
+		return cache.value
+	}
+	cache := temperatureCache{value: GetGpuTemperatureBySensor(sensor), updated: time.Now()}
+	gpuCache[sensor] = cache
+	return cache.value
+}
+
+// GetGpuTemperatureBySensor will return GPU temperature for a sensor from GetGpuSensors.
+// Empty sensor name will return temperature of automatically detected GPU
+func GetGpuTemperatureBySensor(sensor string) float32 {
+	if len(sensor) == 0 {
+		return GetGpuTemperature()
+	}
+
+	if strings.HasPrefix(sensor, nvidiaPrefix) {
+		index := strings.TrimPrefix(sensor, nvidiaPrefix)
+		if _, err := strconv.Atoi(index); err != nil {
+			return 0
+		}
+
+		cmd := exec.Command("nvidia-smi", "-i", index, "--query-gpu=temperature.gpu", "--format=csv,noheader,nounits")
+		output, err := cmd.Output()
+		if err != nil {
+			return 0
+		}
+
+		temp, err := strconv.Atoi(strings.TrimSpace(string(output)))
+		if err != nil {
+			return 0
+		}
+		return float32(temp)
+	}
+
+	if m, _ := regexp.MatchString("^hwmon[0-9]+$", sensor); !m {
+		return 0
+	}
+
+	hwmonDir := "/sys/class/hwmon"
+	entries, err := os.ReadDir(hwmonDir)
+	if err != nil {
+		return 0
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == sensor {
+			return getHwMonTemperature(hwmonDir, entry)
+		}
+	}
+	return 0
+}
+
 // getHwMonTemperature will return temperature for given entry
 func getHwMonTemperature(hwmonDir string, entry os.DirEntry) float32 {
 	tempFile := filepath.Join(hwmonDir, entry.Name(), "temp1_input")